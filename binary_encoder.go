@@ -0,0 +1,42 @@
+package optional
+
+import (
+	"github.com/LNMMusic/optional/internal/binconv"
+	"github.com/LNMMusic/optional/internal/decodeerr"
+)
+
+// Binary encoding
+// An Option is encoded as a tag byte: 0 for None, or 1 for Some followed by the inner value
+// (encoded with its encoding.BinaryMarshaler or with gob).
+// The gob methods use the same encoding, as gob can not reach the unexported value of an Option.
+
+// MarshalBinary indicates how to marshal an Option into binary data.
+func (o Option[T]) MarshalBinary() (data []byte, err error) {
+	return binconv.Marshal(o.value != nil, o.value)
+}
+
+// UnmarshalBinary indicates how to unmarshal binary data into an Option (errors as *DecodeError).
+func (o *Option[T]) UnmarshalBinary(data []byte) (err error) {
+	var v T
+	some, err := binconv.Unmarshal(data, &v)
+	if err != nil {
+		err = decodeerr.New("binary", o.innerType(), "", err)
+		return
+	}
+
+	o.value = nil
+	if some {
+		o.value = &v
+	}
+	return
+}
+
+// GobEncode indicates how to encode an Option with encoding/gob.
+func (o Option[T]) GobEncode() (data []byte, err error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode indicates how to decode an Option with encoding/gob.
+func (o *Option[T]) GobDecode(data []byte) (err error) {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,141 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type gobAddress struct {
+	Street Option[string]
+	Number Option[int]
+}
+
+type gobUser struct {
+	ID        int64
+	Name      Option[string]
+	Age       Option[int]
+	Score     Option[float64]
+	Tags      Option[[]string]
+	CreatedAt Option[time.Time]
+	Address   Option[gobAddress]
+	Others    []gobAddress
+	Deleted   Option[bool]
+}
+
+// requireGobRoundTrip encodes and decodes v with gob and checks the result is the same.
+func requireGobRoundTrip[T any](t *testing.T, v T) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	require.NoError(t, err)
+	var decoded T
+	err = gob.NewDecoder(&buf).Decode(&decoded)
+	require.NoError(t, err)
+	require.Equal(t, v, decoded)
+}
+
+// Tests for the Option gob encoding
+func TestOption_Gob(t *testing.T) {
+	t.Run("Gob - scalars", func(t *testing.T) {
+		requireGobRoundTrip(t, Some("hello"))
+		requireGobRoundTrip(t, Some(0))
+		requireGobRoundTrip(t, Some(-1.5))
+		requireGobRoundTrip(t, Some(true))
+		requireGobRoundTrip(t, None[string]())
+	})
+
+	t.Run("Gob - slices", func(t *testing.T) {
+		requireGobRoundTrip(t, Some([]int{1, 2, 3}))
+		requireGobRoundTrip(t, []Option[int]{Some(1), None[int](), Some(3)})
+	})
+
+	t.Run("Gob - nested structs", func(t *testing.T) {
+		requireGobRoundTrip(t, gobUser{
+			ID:        1,
+			Name:      Some("Mary"),
+			Age:       Some(0),
+			Tags:      Some([]string{"a"}),
+			CreatedAt: Some(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)),
+			Address:   Some(gobAddress{Street: Some("Main")}),
+			Others:    []gobAddress{{Number: Some(7)}, {}},
+			Deleted:   Some(false),
+		})
+		requireGobRoundTrip(t, gobUser{ID: 2})
+	})
+
+	t.Run("Gob - none overrides a previous value", func(t *testing.T) {
+		// arrange
+		var buf bytes.Buffer
+		err := gob.NewEncoder(&buf).Encode(None[int]())
+		require.NoError(t, err)
+
+		// act
+		option := Some(1)
+		err = gob.NewDecoder(&buf).Decode(&option)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, option.IsSome())
+	})
+}
+
+// Tests for Option MarshalBinary and UnmarshalBinary
+func TestOption_Binary(t *testing.T) {
+	t.Run("Binary - tag byte prefix", func(t *testing.T) {
+		// act
+		none, err := None[int]().MarshalBinary()
+		require.NoError(t, err)
+		some, err := Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)).MarshalBinary()
+		require.NoError(t, err)
+
+		// assert
+		require.Equal(t, []byte{0}, none)
+		inner, err := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{1}, inner...), some)
+	})
+
+	t.Run("Binary - round trip", func(t *testing.T) {
+		// arrange
+		data, err := Some(gobAddress{Street: Some("Main")}).MarshalBinary()
+		require.NoError(t, err)
+
+		// act
+		var option Option[gobAddress]
+		err = option.UnmarshalBinary(data)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, gobAddress{Street: Some("Main")}, option.Unwrap())
+	})
+
+	// errors
+	type errorCase struct {
+		title string
+		data  []byte
+	}
+	errorCases := []errorCase{
+		{title: "Binary - empty data", data: nil},
+		{title: "Binary - unknown tag", data: []byte{2}},
+		{title: "Binary - trailing data after none", data: []byte{0, 1}},
+		{title: "Binary - invalid inner value", data: []byte{1, 0xff}},
+	}
+	for _, c := range errorCases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			option := Some(1)
+			err := option.UnmarshalBinary(c.data)
+
+			// assert
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			require.Equal(t, "binary", decodeErr.Format)
+			require.Equal(t, 1, option.Unwrap())
+		})
+	}
+}
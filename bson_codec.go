@@ -0,0 +1,112 @@
+package optional
+
+import (
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/nullable"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var (
+	tValueMarshaler   = reflect.TypeOf((*bsoncodec.ValueMarshaler)(nil)).Elem()
+	tValueUnmarshaler = reflect.TypeOf((*bsoncodec.ValueUnmarshaler)(nil)).Elem()
+)
+
+// RegisterBSONCodecs registers the Option and nullable.Null codecs on rb.
+// Unlike MarshalBSONValue and UnmarshalBSONValue (which always use the default registry),
+// the codecs encode and decode the inner values with the registry being built,
+// so the custom codecs of the caller are also applied inside Options.
+//
+// As Option implements bson.ValueMarshaler and bson.ValueUnmarshaler, its codecs take over
+// the hooks of those interfaces, falling back to the default behaviour for any other type.
+//
+//	rb := optional.RegisterBSONCodecs(bson.NewRegistryBuilder())
+//	client, err := mongo.Connect(ctx, options.Client().SetRegistry(rb.Build()))
+func RegisterBSONCodecs(rb *bsoncodec.RegistryBuilder) *bsoncodec.RegistryBuilder {
+	rb.RegisterHookEncoder(tValueMarshaler, bsoncodec.ValueEncoderFunc(optionEncodeValue))
+	rb.RegisterHookDecoder(tValueUnmarshaler, bsoncodec.ValueDecoderFunc(optionDecodeValue))
+	nullable.RegisterBSONCodecs(rb)
+	return rb
+}
+
+// optionEncodeValue is the bsoncodec.ValueEncoderFunc of Option.
+// - None is encoded as null
+// - Some is encoded with the encoder of T in the registry
+func optionEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) (err error) {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+
+	opt, ok := asOptionReflector(val)
+	if !ok {
+		// other value marshalers
+		return bsoncodec.DefaultValueEncoders{}.ValueMarshalerEncodeValue(ec, vw, val)
+	}
+	if !opt.IsSome() {
+		return vw.WriteNull()
+	}
+
+	inner := opt.innerValue()
+	enc, err := ec.LookupEncoder(inner.Type())
+	if err != nil {
+		return
+	}
+	err = enc.EncodeValue(ec, vw, inner)
+	return
+}
+
+// optionDecodeValue is the bsoncodec.ValueDecoderFunc of Option.
+// - null and undefined are decoded as None (rejected by StrictOption)
+// - any other value is decoded as Some with the decoder of T in the registry (errors as *DecodeError)
+func optionDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) (err error) {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+
+	var opt optionReflector
+	if val.CanAddr() {
+		opt, _ = val.Addr().Interface().(optionReflector)
+	}
+	if opt == nil {
+		// other value unmarshalers
+		return bsoncodec.DefaultValueDecoders{}.ValueUnmarshalerDecodeValue(dc, vr, val)
+	}
+
+	switch vr.Type() {
+	case bsontype.Null, bsontype.Undefined:
+		if _, ok := opt.(nullRejecter); ok {
+			return decodeerr.BSON(opt.innerType(), vr.Type(), nil, ErrNullNotAllowed)
+		}
+		opt.reset()
+		if vr.Type() == bsontype.Null {
+			return vr.ReadNull()
+		}
+		return vr.ReadUndefined()
+	}
+
+	// the raw value is kept for the errors
+	t, data, err := bsonrw.Copier{}.CopyValueToBytes(vr)
+	if err != nil {
+		return
+	}
+
+	inner := reflect.New(opt.innerType()).Elem()
+	dec, err := dc.LookupDecoder(inner.Type())
+	if err != nil {
+		return
+	}
+	if err = dec.DecodeValue(dc, bsonrw.NewBSONValueReader(t, data), inner); err != nil {
+		return decodeerr.BSON(inner.Type(), t, data, err)
+	}
+	opt.setInner(inner)
+	return
+}
@@ -0,0 +1,210 @@
+package optional
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// celsius is encoded by a custom codec as a string (e.g. "21.5C")
+type celsius float64
+
+var tCelsius = reflect.TypeOf(celsius(0))
+
+func celsiusEncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	return vw.WriteString(strconv.FormatFloat(val.Float(), 'f', -1, 64) + "C")
+}
+
+func celsiusDecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	s, err := vr.ReadString()
+	if err != nil {
+		return err
+	}
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s, "C"), 64)
+	if err != nil {
+		return err
+	}
+	val.SetFloat(f)
+	return nil
+}
+
+// upper is a value marshaler that is not an Option
+type upper string
+
+func (u upper) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(strings.ToUpper(string(u)))
+}
+
+func (u *upper) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var s string
+	if err := bson.UnmarshalValue(t, data, &s); err != nil {
+		return err
+	}
+	*u = upper(strings.ToLower(s))
+	return nil
+}
+
+func newCodecRegistry() *bsoncodec.Registry {
+	rb := bson.NewRegistryBuilder()
+	rb.RegisterTypeEncoder(tCelsius, bsoncodec.ValueEncoderFunc(celsiusEncodeValue))
+	rb.RegisterTypeDecoder(tCelsius, bsoncodec.ValueDecoderFunc(celsiusDecodeValue))
+	return RegisterBSONCodecs(rb).Build()
+}
+
+// Tests for the bson codecs
+func TestBSONCodecs_Encode(t *testing.T) {
+	type schema struct {
+		Temperature Option[celsius]                `bson:"temperature"`
+		Average     nullable.Null[celsius]         `bson:"average"`
+		Nested      Option[nullable.Null[celsius]] `bson:"nested"`
+		Pointer     *Option[celsius]               `bson:"pointer"`
+		Upper       upper                          `bson:"upper"`
+	}
+	registry := newCodecRegistry()
+
+	t.Run("succeed to marshal - inner values use the custom codecs", func(t *testing.T) {
+		// arrange
+		ptr := Some(celsius(-1))
+		s := schema{
+			Temperature: Some(celsius(21.5)),
+			Average:     nullable.Some(celsius(20)),
+			Nested:      Some(nullable.Some(celsius(19))),
+			Pointer:     &ptr,
+			Upper:       "hello",
+		}
+
+		// act
+		bytes, err := bson.MarshalWithRegistry(registry, s)
+
+		// assert
+		require.NoError(t, err)
+		expectedBytes, err := bson.Marshal(bson.D{
+			{Key: "temperature", Value: "21.5C"},
+			{Key: "average", Value: "20C"},
+			{Key: "nested", Value: "19C"},
+			{Key: "pointer", Value: "-1C"},
+			{Key: "upper", Value: "HELLO"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, expectedBytes, bytes)
+	})
+
+	t.Run("succeed to marshal - none and null", func(t *testing.T) {
+		// arrange
+		s := schema{
+			Temperature: None[celsius](),
+			Average:     nullable.None[celsius](),
+			Nested:      Some(nullable.None[celsius]()),
+		}
+
+		// act
+		bytes, err := bson.MarshalWithRegistry(registry, s)
+
+		// assert
+		require.NoError(t, err)
+		expectedBytes, err := bson.Marshal(bson.D{
+			{Key: "temperature", Value: nil},
+			{Key: "average", Value: nil},
+			{Key: "nested", Value: nil},
+			{Key: "pointer", Value: nil},
+			{Key: "upper", Value: ""},
+		})
+		require.NoError(t, err)
+		require.Equal(t, expectedBytes, bytes)
+	})
+
+	t.Run("default registry ignores the custom codecs", func(t *testing.T) {
+		// arrange
+		s := struct {
+			Temperature Option[celsius] `bson:"temperature"`
+		}{Temperature: Some(celsius(21.5))}
+
+		// act
+		bytes, err := bson.Marshal(s)
+
+		// assert
+		require.NoError(t, err)
+		expectedBytes, err := bson.Marshal(bson.D{{Key: "temperature", Value: 21.5}})
+		require.NoError(t, err)
+		require.Equal(t, expectedBytes, bytes)
+	})
+}
+
+func TestBSONCodecs_Decode(t *testing.T) {
+	type schema struct {
+		Temperature Option[celsius]                `bson:"temperature"`
+		Average     nullable.Null[celsius]         `bson:"average"`
+		Nested      Option[nullable.Null[celsius]] `bson:"nested"`
+		Pointer     *Option[celsius]               `bson:"pointer"`
+		Upper       upper                          `bson:"upper"`
+	}
+	registry := newCodecRegistry()
+
+	t.Run("succeed to unmarshal - inner values use the custom codecs", func(t *testing.T) {
+		// arrange
+		bytes, err := bson.Marshal(bson.D{
+			{Key: "temperature", Value: "21.5C"},
+			{Key: "average", Value: "20C"},
+			{Key: "nested", Value: "19C"},
+			{Key: "pointer", Value: "-1C"},
+			{Key: "upper", Value: "HELLO"},
+		})
+		require.NoError(t, err)
+
+		// act
+		var s schema
+		err = bson.UnmarshalWithRegistry(registry, bytes, &s)
+
+		// assert
+		ptr := Some(celsius(-1))
+		expectedSchema := schema{
+			Temperature: Some(celsius(21.5)),
+			Average:     nullable.Some(celsius(20)),
+			Nested:      Some(nullable.Some(celsius(19))),
+			Pointer:     &ptr,
+			Upper:       "hello",
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, s)
+	})
+
+	t.Run("succeed to unmarshal - null into some values", func(t *testing.T) {
+		// arrange
+		bytes, err := bson.Marshal(bson.D{
+			{Key: "temperature", Value: nil},
+			{Key: "average", Value: nil},
+		})
+		require.NoError(t, err)
+
+		// act
+		s := schema{Temperature: Some(celsius(1)), Average: nullable.Some(celsius(1))}
+		err = bson.UnmarshalWithRegistry(registry, bytes, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, s.Temperature.IsSome())
+		require.True(t, s.Average.IsNull())
+	})
+
+	t.Run("fail to unmarshal - inner codec error", func(t *testing.T) {
+		// arrange
+		bytes, err := bson.Marshal(bson.D{{Key: "temperature", Value: "hot"}})
+		require.NoError(t, err)
+
+		// act
+		var s schema
+		err = bson.UnmarshalWithRegistry(registry, bytes, &s)
+
+		// assert
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "temperature")
+	})
+}
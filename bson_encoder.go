@@ -1,22 +1,51 @@
 package optional
 
 import (
+	"github.com/LNMMusic/optional/internal/decodeerr"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
 // UnmarshalBSONValue indicates how to unmarshal a bson value into an Option.
+// The common scalars are decoded without reflection (see bson_fastpath.go).
+// Errors are returned as *DecodeError.
 func (o *Option[T]) UnmarshalBSONValue(t bsontype.Type, data []byte) (err error) {
+	// null
+	if t == bsontype.Null || t == bsontype.Undefined {
+		o.value = nil
+		return
+	}
+
+	// fast path
+	var v T
+	if unmarshalBSONFast(t, data, &v) {
+		o.value = &v
+		return
+	}
+
 	// encapsulate data into bson.RawValue
 	rv := bson.RawValue{Type: t, Value: data}
 
 	// unmarshal bson.RawValue into o.value
-	err = rv.Unmarshal(&o.value)
+	err = decodeerr.BSON(o.innerType(), t, data, rv.Unmarshal(&o.value))
 	return
 }
 
 // MarshalBSONValue indicates how to marshal an Option into a bson value.
+// The common scalars are encoded without reflection (see bson_fastpath.go).
 func (o Option[T]) MarshalBSONValue() (t bsontype.Type, data []byte, err error) {
+	// null
+	if o.value == nil {
+		t = bsontype.Null
+		return
+	}
+
+	// fast path
+	var ok bool
+	if t, data, ok = marshalBSONFast(o.value); ok {
+		return
+	}
+
 	// marshal o.value into bson.RawValue
 	t, data, err = bson.MarshalValue(o.value)
 	return
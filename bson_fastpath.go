@@ -0,0 +1,118 @@
+package optional
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// BSON fast paths
+// string, int, int32, int64, float64, bool, datetimes (time.Time and primitive.DateTime) and
+// primitive.ObjectID are encoded and decoded with bsoncore, without the reflection of the default registry.
+// The wire format is the same as the default registry, and decoding tolerates lossless numeric widening
+// (e.g. int32 -> int64). Any other case falls back to the default registry, so the errors are also the same.
+
+// marshalBSONFast returns the bson encoding of the value pointed by v.
+// ok is false if the value must be encoded by the default registry.
+func marshalBSONFast(v any) (t bsontype.Type, data []byte, ok bool) {
+	switch v := v.(type) {
+	case *string:
+		return bsontype.String, bsoncore.AppendString(nil, *v), true
+	case *int:
+		// as the default registry, int is encoded as int32 if it fits
+		if *v >= math.MinInt32 && *v <= math.MaxInt32 {
+			return bsontype.Int32, bsoncore.AppendInt32(nil, int32(*v)), true
+		}
+		return bsontype.Int64, bsoncore.AppendInt64(nil, int64(*v)), true
+	case *int32:
+		return bsontype.Int32, bsoncore.AppendInt32(nil, *v), true
+	case *int64:
+		return bsontype.Int64, bsoncore.AppendInt64(nil, *v), true
+	case *float64:
+		return bsontype.Double, bsoncore.AppendDouble(nil, *v), true
+	case *bool:
+		return bsontype.Boolean, bsoncore.AppendBoolean(nil, *v), true
+	case *time.Time:
+		return bsontype.DateTime, bsoncore.AppendDateTime(nil, int64(primitive.NewDateTimeFromTime(*v))), true
+	case *primitive.DateTime:
+		return bsontype.DateTime, bsoncore.AppendDateTime(nil, int64(*v)), true
+	case *primitive.ObjectID:
+		return bsontype.ObjectID, bsoncore.AppendObjectID(nil, *v), true
+	}
+	return
+}
+
+// unmarshalBSONFast decodes the bson value of type t into the value pointed by v.
+// ok is false if the value must be decoded by the default registry.
+func unmarshalBSONFast(t bsontype.Type, data []byte, v any) (ok bool) {
+	var rem []byte
+	switch v := v.(type) {
+	case *string:
+		if t == bsontype.String {
+			*v, rem, ok = bsoncore.ReadString(data)
+		}
+	case *int:
+		switch t {
+		case bsontype.Int32:
+			var i32 int32
+			i32, rem, ok = bsoncore.ReadInt32(data)
+			*v = int(i32)
+		case bsontype.Int64:
+			var i64 int64
+			i64, rem, ok = bsoncore.ReadInt64(data)
+			if strconv.IntSize == 32 && (i64 < math.MinInt32 || i64 > math.MaxInt32) {
+				return false
+			}
+			*v = int(i64)
+		}
+	case *int32:
+		if t == bsontype.Int32 {
+			*v, rem, ok = bsoncore.ReadInt32(data)
+		}
+	case *int64:
+		switch t {
+		case bsontype.Int32:
+			var i32 int32
+			i32, rem, ok = bsoncore.ReadInt32(data)
+			*v = int64(i32)
+		case bsontype.Int64:
+			*v, rem, ok = bsoncore.ReadInt64(data)
+		}
+	case *float64:
+		switch t {
+		case bsontype.Double:
+			*v, rem, ok = bsoncore.ReadDouble(data)
+		case bsontype.Int32:
+			var i32 int32
+			i32, rem, ok = bsoncore.ReadInt32(data)
+			*v = float64(i32)
+		}
+	case *bool:
+		if t == bsontype.Boolean {
+			*v, rem, ok = bsoncore.ReadBoolean(data)
+		}
+	case *time.Time:
+		if t == bsontype.DateTime {
+			var dt int64
+			dt, rem, ok = bsoncore.ReadDateTime(data)
+			*v = primitive.DateTime(dt).Time().UTC()
+		}
+	case *primitive.DateTime:
+		if t == bsontype.DateTime {
+			var dt int64
+			dt, rem, ok = bsoncore.ReadDateTime(data)
+			*v = primitive.DateTime(dt)
+		}
+	case *primitive.ObjectID:
+		if t == bsontype.ObjectID {
+			*v, rem, ok = bsoncore.ReadObjectID(data)
+		}
+	}
+
+	// the value must use all the data
+	return ok && len(rem) == 0
+}
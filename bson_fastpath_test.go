@@ -0,0 +1,239 @@
+package optional
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// requireBSONMarshalCompatible checks that the Option encodes value as the default registry does.
+func requireBSONMarshalCompatible[T any](t *testing.T, value T) {
+	t.Helper()
+
+	expectedType, expectedData, err := bson.MarshalValue(&value)
+	require.NoError(t, err)
+	typ, data, err := Some(value).MarshalBSONValue()
+	require.NoError(t, err)
+	require.Equal(t, expectedType, typ)
+	require.Equal(t, expectedData, data)
+}
+
+// requireBSONUnmarshalCompatible checks that the Option decodes value as the default registry does.
+func requireBSONUnmarshalCompatible[T any](t *testing.T, value any) {
+	t.Helper()
+
+	typ, data, err := bson.MarshalValue(value)
+	require.NoError(t, err)
+
+	var expected *T
+	expectedErr := bson.RawValue{Type: typ, Value: data}.Unmarshal(&expected)
+	var option Option[T]
+	err = option.UnmarshalBSONValue(typ, data)
+	if expectedErr != nil {
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.EqualError(t, decodeErr.Err, expectedErr.Error())
+		return
+	}
+	require.NoError(t, err)
+	require.True(t, option.IsSome())
+	require.Equal(t, *expected, option.Unwrap())
+}
+
+// Tests for the BSON fast path encoding
+func TestOption_MarshalBSONFastPath(t *testing.T) {
+	t.Run("BSON - scalars", func(t *testing.T) {
+		requireBSONMarshalCompatible(t, "")
+		requireBSONMarshalCompatible(t, "hello ñ 世界")
+		requireBSONMarshalCompatible(t, 0)
+		requireBSONMarshalCompatible(t, math.MaxInt32)
+		requireBSONMarshalCompatible(t, math.MaxInt32+1)
+		requireBSONMarshalCompatible(t, math.MinInt32-1)
+		requireBSONMarshalCompatible(t, int32(math.MinInt32))
+		requireBSONMarshalCompatible(t, int64(1))
+		requireBSONMarshalCompatible(t, int64(math.MaxInt64))
+		requireBSONMarshalCompatible(t, 1.5)
+		requireBSONMarshalCompatible(t, math.Inf(-1))
+		requireBSONMarshalCompatible(t, true)
+		requireBSONMarshalCompatible(t, false)
+	})
+
+	t.Run("BSON - datetimes and object ids", func(t *testing.T) {
+		requireBSONMarshalCompatible(t, time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC))
+		requireBSONMarshalCompatible(t, time.Date(1900, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)))
+		requireBSONMarshalCompatible(t, time.Time{})
+		requireBSONMarshalCompatible(t, primitive.DateTime(1700000000000))
+		requireBSONMarshalCompatible(t, primitive.NewObjectID())
+	})
+
+	t.Run("BSON - none", func(t *testing.T) {
+		expectedType, expectedData, err := bson.MarshalValue((*string)(nil))
+		require.NoError(t, err)
+		typ, data, err := None[string]().MarshalBSONValue()
+		require.NoError(t, err)
+		require.Equal(t, expectedType, typ)
+		require.Equal(t, len(expectedData), len(data))
+	})
+}
+
+// Tests for the BSON fast path decoding
+func TestOption_UnmarshalBSONFastPath(t *testing.T) {
+	t.Run("BSON - string", func(t *testing.T) {
+		requireBSONUnmarshalCompatible[string](t, "hello")
+		requireBSONUnmarshalCompatible[string](t, "")
+		requireBSONUnmarshalCompatible[string](t, int32(1))
+	})
+
+	t.Run("BSON - numeric widening", func(t *testing.T) {
+		requireBSONUnmarshalCompatible[int](t, int32(42))
+		requireBSONUnmarshalCompatible[int](t, int64(math.MaxInt64))
+		requireBSONUnmarshalCompatible[int](t, 1.0)
+		requireBSONUnmarshalCompatible[int32](t, int32(-42))
+		requireBSONUnmarshalCompatible[int32](t, int64(42))
+		requireBSONUnmarshalCompatible[int32](t, int64(math.MaxInt64))
+		requireBSONUnmarshalCompatible[int64](t, int32(math.MinInt32))
+		requireBSONUnmarshalCompatible[int64](t, int64(math.MinInt64))
+		requireBSONUnmarshalCompatible[int64](t, 1.5)
+		requireBSONUnmarshalCompatible[float64](t, 1.5)
+		requireBSONUnmarshalCompatible[float64](t, int32(42))
+		requireBSONUnmarshalCompatible[float64](t, int64(42))
+		requireBSONUnmarshalCompatible[float64](t, "1.5")
+	})
+
+	t.Run("BSON - bool", func(t *testing.T) {
+		requireBSONUnmarshalCompatible[bool](t, true)
+		requireBSONUnmarshalCompatible[bool](t, false)
+		requireBSONUnmarshalCompatible[bool](t, int32(1))
+	})
+
+	t.Run("BSON - datetimes and object ids", func(t *testing.T) {
+		now := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("", 3600))
+		requireBSONUnmarshalCompatible[time.Time](t, now)
+		requireBSONUnmarshalCompatible[time.Time](t, primitive.NewDateTimeFromTime(now))
+		requireBSONUnmarshalCompatible[time.Time](t, int64(1700000000000))
+		requireBSONUnmarshalCompatible[primitive.DateTime](t, now)
+		requireBSONUnmarshalCompatible[primitive.ObjectID](t, primitive.NewObjectID())
+		requireBSONUnmarshalCompatible[primitive.ObjectID](t, "not an id")
+	})
+
+	t.Run("BSON - null and undefined", func(t *testing.T) {
+		for _, typ := range []bsontype.Type{bsontype.Null, bsontype.Undefined} {
+			option := Some("hello")
+			err := option.UnmarshalBSONValue(typ, nil)
+			require.NoError(t, err)
+			require.False(t, option.IsSome())
+		}
+	})
+
+	t.Run("BSON - truncated data", func(t *testing.T) {
+		var option Option[int64]
+		err := option.UnmarshalBSONValue(bsontype.Int64, []byte{1, 2, 3})
+		require.Error(t, err)
+	})
+}
+
+// reflectBSONOption is the reflection based implementation of the Option bson encoding.
+// It is the baseline of the fast path benchmarks.
+type reflectBSONOption[T any] struct {
+	value *T
+}
+
+func (o *reflectBSONOption[T]) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	return bson.RawValue{Type: t, Value: data}.Unmarshal(&o.value)
+}
+
+func (o reflectBSONOption[T]) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(o.value)
+}
+
+type benchmarkBSONDocument struct {
+	ID        Option[primitive.ObjectID] `bson:"_id"`
+	Name      Option[string]             `bson:"name"`
+	Age       Option[int]                `bson:"age"`
+	Score     Option[float64]            `bson:"score"`
+	Active    Option[bool]               `bson:"active"`
+	CreatedAt Option[time.Time]          `bson:"created_at"`
+}
+
+type benchmarkReflectBSONDocument struct {
+	ID        reflectBSONOption[primitive.ObjectID] `bson:"_id"`
+	Name      reflectBSONOption[string]             `bson:"name"`
+	Age       reflectBSONOption[int]                `bson:"age"`
+	Score     reflectBSONOption[float64]            `bson:"score"`
+	Active    reflectBSONOption[bool]               `bson:"active"`
+	CreatedAt reflectBSONOption[time.Time]          `bson:"created_at"`
+}
+
+func benchmarkBSONPayload() []byte {
+	data, err := bson.Marshal(benchmarkBSONDocument{
+		ID:        Some(primitive.NewObjectID()),
+		Name:      Some("Mary"),
+		Age:       Some(20),
+		Score:     Some(1.5),
+		Active:    Some(true),
+		CreatedAt: Some(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func BenchmarkOption_MarshalBSON(b *testing.B) {
+	data := benchmarkBSONPayload()
+	var doc benchmarkBSONDocument
+	var reflectDoc benchmarkReflectBSONDocument
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		b.Fatal(err)
+	}
+	if err := bson.Unmarshal(data, &reflectDoc); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bson.Marshal(doc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := bson.Marshal(reflectDoc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkOption_UnmarshalBSON(b *testing.B) {
+	data := benchmarkBSONPayload()
+
+	b.Run("fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var doc benchmarkBSONDocument
+			if err := bson.Unmarshal(data, &doc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var doc benchmarkReflectBSONDocument
+			if err := bson.Unmarshal(data, &doc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
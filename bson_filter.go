@@ -0,0 +1,131 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	ErrFilterNotStruct       = errors.New("filter criteria must be a struct")
+	ErrFilterUnknownOperator = errors.New("unknown filter operator")
+)
+
+// filterOperators maps the supported `optfilter` tag operators to their mongo operator.
+// eq is the default operator and is encoded as an implicit equality.
+// The tag is shared with sqlbuild.Where: the fields with its operators only (like) are skipped.
+var filterOperators = map[string]string{
+	"eq":     "$eq",
+	"ne":     "$ne",
+	"gt":     "$gt",
+	"gte":    "$gte",
+	"lt":     "$lt",
+	"lte":    "$lte",
+	"in":     "$in",
+	"nin":    "$nin",
+	"regex":  "$regex",
+	"exists": "$exists",
+}
+
+// BSONFilter builds a mongo filter from a struct of Option criteria.
+//   - None criteria are skipped
+//   - Some criteria are added to the filter under the key of the bson tag (or the lowercased field name)
+//   - the operator is chosen by the `optfilter` tag (eq by default)
+//     > eq, ne, gt, gte, lt, lte, in, nin, regex, exists
+//     > regex accepts its options after a comma, e.g. `optfilter:"regex,i"`
+//     > `optfilter:"-"` and the operators of sqlbuild.Where only (like) skip the field
+//
+// Fields that are not Options are ignored, and untagged embedded structs are flattened (as sqlbuild.Where does).
+// Criteria sharing the same key are merged into a single document, e.g.
+//
+//	type criteria struct {
+//		MinAge Option[int] `bson:"age" optfilter:"gte"`
+//		MaxAge Option[int] `bson:"age" optfilter:"lte"`
+//	}
+//
+// with both Some values results in {"age": {"$gte": min, "$lte": max}}
+func BSONFilter(criteria any) (filter bson.D, err error) {
+	rv := reflect.ValueOf(criteria)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: got %T", ErrFilterNotStruct, criteria)
+		return
+	}
+
+	filter = bson.D{}
+	for _, f := range structs.Fields(rv.Type(), "bson") {
+		sf := f.StructField
+
+		// operator
+		op, opts, skip, known := structs.FilterOperator(sf)
+		if !known {
+			err = fmt.Errorf("%w: %q on field %s", ErrFilterUnknownOperator, op, sf.Name)
+			return
+		}
+		mongoOp, ok := filterOperators[op]
+		if skip || !ok {
+			continue
+		}
+
+		// criteria
+		opt, ok := asOptionReflector(rv.FieldByIndex(f.Index))
+		if !ok || !opt.IsSome() {
+			continue
+		}
+		key := bsonKey(sf)
+		if key == "" {
+			continue
+		}
+
+		// filter
+		cond := bson.D{{Key: mongoOp, Value: opt.inner()}}
+		if op == "regex" && opts != "" {
+			cond = append(cond, bson.E{Key: "$options", Value: opts})
+		}
+		filter = appendFilter(filter, key, op, cond)
+	}
+
+	return
+}
+
+// bsonKey returns the key of a struct field following the bson struct tag rules.
+// An empty key means the field is skipped.
+func bsonKey(sf reflect.StructField) (key string) {
+	key, _, _ = strings.Cut(sf.Tag.Get("bson"), ",")
+	switch key {
+	case "-":
+		key = ""
+	case "":
+		key = strings.ToLower(sf.Name)
+	}
+	return
+}
+
+// appendFilter adds a condition to the filter, merging it with a previous condition on the same key.
+func appendFilter(filter bson.D, key string, op string, cond bson.D) bson.D {
+	for i := range filter {
+		if filter[i].Key != key {
+			continue
+		}
+
+		// merge with the previous condition (an implicit equality is turned into $eq)
+		prev, ok := filter[i].Value.(bson.D)
+		if !ok {
+			prev = bson.D{{Key: "$eq", Value: filter[i].Value}}
+		}
+		filter[i].Value = append(prev, cond...)
+		return filter
+	}
+
+	// implicit equality
+	if op == "eq" {
+		return append(filter, bson.E{Key: key, Value: cond[0].Value})
+	}
+	return append(filter, bson.E{Key: key, Value: cond})
+}
@@ -0,0 +1,145 @@
+package optional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Tests for BSONFilter
+func TestBSONFilter(t *testing.T) {
+	type criteria struct {
+		Name     Option[string]   `bson:"name"`
+		Email    Option[string]   `bson:"email" optfilter:"regex,i"`
+		MinAge   Option[int]      `bson:"age" optfilter:"gte"`
+		MaxAge   Option[int]      `bson:"age" optfilter:"lt"`
+		Tags     Option[[]string] `bson:"tags" optfilter:"in"`
+		Verified Option[bool]     `bson:"verified" optfilter:"exists"`
+		Ignored  Option[string]   `bson:"ignored" optfilter:"-"`
+		Plain    string           `bson:"plain"`
+		Country  Option[string]
+	}
+
+	t.Run("succeed to build - all criteria none", func(t *testing.T) {
+		// arrange
+		c := criteria{Plain: "plain"}
+
+		// act
+		filter, err := BSONFilter(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, bson.D{}, filter)
+	})
+
+	t.Run("succeed to build - implicit equality", func(t *testing.T) {
+		// arrange
+		c := criteria{Name: Some("Mary"), Country: Some("AR")}
+
+		// act
+		filter, err := BSONFilter(&c)
+
+		// assert
+		expectedFilter := bson.D{{Key: "name", Value: "Mary"}, {Key: "country", Value: "AR"}}
+		require.NoError(t, err)
+		require.Equal(t, expectedFilter, filter)
+	})
+
+	t.Run("succeed to build - embedded criteria are flattened", func(t *testing.T) {
+		// arrange
+		type Paging struct {
+			After Option[string] `bson:"_id" optfilter:"gt"`
+		}
+		type embedding struct {
+			Paging
+			Name Option[string] `bson:"name"`
+		}
+		c := embedding{Paging: Paging{After: Some("a1")}, Name: Some("Mary")}
+
+		// act
+		filter, err := BSONFilter(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: "a1"}}}, {Key: "name", Value: "Mary"}}, filter)
+	})
+
+	t.Run("succeed to build - operators", func(t *testing.T) {
+		// arrange
+		c := criteria{
+			Email:    Some("@mail.com$"),
+			MinAge:   Some(18),
+			Tags:     Some([]string{"a", "b"}),
+			Verified: Some(true),
+			Ignored:  Some("ignored"),
+		}
+
+		// act
+		filter, err := BSONFilter(c)
+
+		// assert
+		expectedFilter := bson.D{
+			{Key: "email", Value: bson.D{{Key: "$regex", Value: "@mail.com$"}, {Key: "$options", Value: "i"}}},
+			{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}}},
+			{Key: "tags", Value: bson.D{{Key: "$in", Value: []string{"a", "b"}}}},
+			{Key: "verified", Value: bson.D{{Key: "$exists", Value: true}}},
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedFilter, filter)
+	})
+
+	t.Run("succeed to build - criteria on the same key are merged", func(t *testing.T) {
+		// arrange
+		c := criteria{MinAge: Some(18), MaxAge: Some(65)}
+
+		// act
+		filter, err := BSONFilter(c)
+
+		// assert
+		expectedFilter := bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}, {Key: "$lt", Value: 65}}}}
+		require.NoError(t, err)
+		require.Equal(t, expectedFilter, filter)
+	})
+
+	t.Run("succeed to build - filter can be marshalled", func(t *testing.T) {
+		// arrange
+		c := criteria{Name: Some("Mary"), MinAge: Some(18)}
+
+		// act
+		filter, err := BSONFilter(c)
+		require.NoError(t, err)
+		bytes, err := bson.Marshal(filter)
+
+		// assert
+		require.NoError(t, err)
+		expectedBytes, err := bson.Marshal(bson.D{{Key: "name", Value: "Mary"}, {Key: "age", Value: bson.D{{Key: "$gte", Value: 18}}}})
+		require.NoError(t, err)
+		require.Equal(t, expectedBytes, bytes)
+	})
+
+	t.Run("fail to build - criteria is not a struct", func(t *testing.T) {
+		// arrange
+		// ...
+
+		// act
+		filter, err := BSONFilter(map[string]Option[int]{})
+
+		// assert
+		require.ErrorIs(t, err, ErrFilterNotStruct)
+		require.Nil(t, filter)
+	})
+
+	t.Run("fail to build - unknown operator", func(t *testing.T) {
+		// arrange
+		type invalid struct {
+			Age Option[int] `optfilter:"between"`
+		}
+
+		// act
+		_, err := BSONFilter(invalid{Age: Some(1)})
+
+		// assert
+		require.ErrorIs(t, err, ErrFilterUnknownOperator)
+	})
+}
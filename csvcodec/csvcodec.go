@@ -0,0 +1,79 @@
+// Package csvcodec decodes and encodes encoding/csv records from and to structs with
+// optional.Option and nullable.Null fields.
+//
+// Columns are mapped to fields by header name with the `csv` tag (or the field name,
+// case insensitive), `csv:"-"` skips a field.
+// - null cells (by default "", "NULL" and `\N`) are decoded as None (Option), Null (Null) or nil (pointers)
+// - any other cell is decoded with the encoding.TextUnmarshaler of the value or strconv for the builtin kinds
+// - fields of any other type are never null, their cells are always decoded as is
+//
+// The null sentinels of the fields (`optional:"null=N/A"`, see optional.MarshalJSON) are also null cells,
+// and the first one is the null cell of the field when encoding.
+package csvcodec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+var (
+	ErrInvalidValue  = errors.New("csvcodec: invalid value")
+	ErrUnknownColumn = errors.New("csvcodec: column is not mapped to any field")
+)
+
+// DefaultNulls are the null cells of a Decoder by default.
+var DefaultNulls = []string{"", "NULL", `\N`}
+
+// CellError is the error of a cell that could not be decoded or encoded.
+type CellError struct {
+	// Line is the line of the cell in the csv input, 0 when encoding.
+	Line int
+	// Column is the header name of the cell.
+	Column string
+	// Field is the name of the struct field, empty if the column is not mapped.
+	Field string
+	// Err is the cause of the error.
+	Err error
+}
+
+// Error returns the message of the error.
+func (e *CellError) Error() string {
+	var b strings.Builder
+	b.WriteString("csvcodec: ")
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "line %d, ", e.Line)
+	}
+	b.WriteString("column " + e.Column)
+	if e.Field != "" {
+		b.WriteString(" into field " + e.Field)
+	}
+	b.WriteString(": " + e.Err.Error())
+	return b.String()
+}
+
+// Unwrap returns the cause of the error.
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// column is a field of a struct mapped to a csv column.
+type column struct {
+	name  string
+	field structs.Field
+}
+
+// columnsOf returns the columns of a struct type, in the order of its fields.
+func columnsOf(t reflect.Type) (columns []column) {
+	for _, f := range structs.Fields(t, "csv") {
+		name := f.Name
+		if name == "" {
+			name = f.StructField.Name
+		}
+		columns = append(columns, column{name: name, field: f})
+	}
+	return
+}
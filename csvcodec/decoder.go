@@ -0,0 +1,177 @@
+package csvcodec
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textfield"
+)
+
+// Decoder reads csv records into structs, one record per struct.
+// The first record is the header, it maps the columns to the fields of the structs.
+type Decoder struct {
+	// Nulls are the null cells of all the columns. NewDecoder sets them to DefaultNulls.
+	Nulls []string
+	// ColumnNulls are the null cells of some columns by header name, replacing Nulls for them
+	// (e.g. {"comment": {`\N`}} so an empty comment is Some("")).
+	ColumnNulls map[string][]string
+	// DisallowUnknownColumns makes Decode fail with ErrUnknownColumn for the header columns
+	// not mapped to any field, they are ignored otherwise.
+	DisallowUnknownColumns bool
+
+	r      *csv.Reader
+	header []string
+
+	// fields of the header columns for the last decoded struct type
+	structType reflect.Type
+	fields     []*structs.Field
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r *csv.Reader) *Decoder {
+	return &Decoder{Nulls: DefaultNulls, r: r}
+}
+
+// Header returns the header of the csv input, reading it if no record was read yet.
+func (d *Decoder) Header() (header []string, err error) {
+	if d.header == nil {
+		var record []string
+		if record, err = d.r.Read(); err != nil {
+			return
+		}
+		d.header = slices.Clone(record)
+	}
+	header = d.header
+	return
+}
+
+// Decode reads the next record into the struct pointed by dst.
+// It returns io.EOF when there are no more records.
+//
+// All the cells are decoded before returning, so the returned error joins
+// a *CellError for each invalid cell (the record is consumed anyway, Decode can be called again).
+func (d *Decoder) Decode(dst any) (err error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: expected a pointer to a struct, got %T", ErrInvalidValue, dst)
+		return
+	}
+
+	if _, err = d.Header(); err != nil {
+		return
+	}
+	record, err := d.r.Read()
+	if err != nil {
+		return
+	}
+	err = d.decodeRecord(record, rv.Elem())
+	return
+}
+
+// DecodeAll reads all the remaining records into the slice pointed by dst.
+// The elements of the slice can be structs or pointers to structs.
+// It stops at the first invalid record.
+func (d *Decoder) DecodeAll(dst any) (err error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		err = fmt.Errorf("%w: expected a pointer to a slice, got %T", ErrInvalidValue, dst)
+		return
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: expected a slice of structs, got %T", ErrInvalidValue, dst)
+		return
+	}
+
+	for {
+		elem := reflect.New(structType)
+		if err = d.Decode(elem.Interface()); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		if elemType.Kind() == reflect.Pointer {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+}
+
+// mapFields maps the header columns to the fields of the struct type t.
+// Columns are matched to the field names exactly first, then case insensitively.
+func (d *Decoder) mapFields(t reflect.Type) {
+	if d.structType == t {
+		return
+	}
+
+	columns := columnsOf(t)
+	d.structType = t
+	d.fields = make([]*structs.Field, len(d.header))
+	for i, name := range d.header {
+		for j := range columns {
+			if columns[j].name == name {
+				d.fields[i] = &columns[j].field
+				break
+			}
+		}
+		if d.fields[i] != nil {
+			continue
+		}
+		for j := range columns {
+			if strings.EqualFold(columns[j].name, name) {
+				d.fields[i] = &columns[j].field
+				break
+			}
+		}
+	}
+}
+
+// decodeRecord decodes the cells of record into the struct value sv.
+func (d *Decoder) decodeRecord(record []string, sv reflect.Value) error {
+	d.mapFields(sv.Type())
+
+	var errs []error
+	for i, cell := range record {
+		if i >= len(d.header) {
+			break
+		}
+		name := d.header[i]
+		line, _ := d.r.FieldPos(i)
+
+		f := d.fields[i]
+		if f == nil {
+			if d.DisallowUnknownColumns {
+				errs = append(errs, &CellError{Line: line, Column: name, Err: ErrUnknownColumn})
+			}
+			continue
+		}
+		if err := textfield.Decode(sv.FieldByIndex(f.Index), []string{cell}, d.isNull(name, f, cell)); err != nil {
+			errs = append(errs, &CellError{Line: line, Column: name, Field: f.StructField.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isNull returns true if the cell of the column mapped to the field f is null:
+// one of the nulls of the column (or of all the columns) or of the sentinels of the field.
+func (d *Decoder) isNull(column string, f *structs.Field, cell string) bool {
+	nulls, ok := d.ColumnNulls[column]
+	if !ok {
+		nulls = d.Nulls
+	}
+	return slices.Contains(nulls, cell) || slices.Contains(sentinel.Nulls(f.StructField), cell)
+}
@@ -0,0 +1,255 @@
+package csvcodec
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	ID       int                            `csv:"id"`
+	Name     optional.Option[string]        `csv:"name"`
+	Age      optional.Option[int]           `csv:"age"`
+	Nickname nullable.Null[string]          `csv:"nickname"`
+	Timeout  optional.Option[time.Duration] `csv:"timeout"`
+	Score    *float64                       `csv:"score"`
+	Comment  string
+	Ignored  string `csv:"-"`
+}
+
+// id is a value with its own sql.Scanner, as uuid or decimal types.
+type id string
+
+func (i *id) Scan(src any) error {
+	*i = id(fmt.Sprint(src))
+	return nil
+}
+
+// code has an IsNull method but is neither an Option nor a Null.
+type code string
+
+func (c code) IsNull() bool {
+	return c == ""
+}
+
+func decoder(data string) *Decoder {
+	return NewDecoder(csv.NewReader(strings.NewReader(data)))
+}
+
+// Tests for Decoder Decode
+func TestDecoder_Decode(t *testing.T) {
+	t.Run("CSV - values and nulls", func(t *testing.T) {
+		// arrange
+		d := decoder("id,name,age,nickname,timeout,score,comment\n" +
+			"1,Mary,20,mary,5s,9.5,hello\n" +
+			"2,NULL,\\N,,,,\n")
+
+		// act
+		var first, second user
+		err1 := d.Decode(&first)
+		err2 := d.Decode(&second)
+		err3 := d.Decode(&second)
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.ErrorIs(t, err3, io.EOF)
+		score := 9.5
+		require.Equal(t, user{ID: 1, Name: optional.Some("Mary"), Age: optional.Some(20), Nickname: nullable.Some("mary"), Timeout: optional.Some(5 * time.Second), Score: &score, Comment: "hello"}, first)
+		require.Equal(t, user{ID: 2, Name: optional.None[string](), Age: optional.None[int](), Nickname: nullable.None[string](), Timeout: optional.None[time.Duration]()}, second)
+	})
+
+	t.Run("CSV - column nulls", func(t *testing.T) {
+		// arrange
+		d := decoder("name,nickname\n,NULL\n")
+		d.ColumnNulls = map[string][]string{"name": {`\N`}, "nickname": {""}}
+
+		// act
+		var u user
+		err := d.Decode(&u)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "", u.Name.Unwrap())
+		require.Equal(t, "NULL", u.Nickname.Unwrap())
+	})
+
+	t.Run("CSV - header matching", func(t *testing.T) {
+		// arrange
+		d := decoder("ID,Comment,unknown\n1,hello,x\n")
+
+		// act
+		var u user
+		err := d.Decode(&u)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1, u.ID)
+		require.Equal(t, "hello", u.Comment)
+	})
+
+	t.Run("CSV - header", func(t *testing.T) {
+		// act
+		d := decoder("id,name\n1,Mary\n")
+		header, err := d.Header()
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"id", "name"}, header)
+	})
+
+	// errors
+	t.Run("CSV - invalid cells", func(t *testing.T) {
+		// arrange
+		d := decoder("id,age,timeout\nx,20,5s\n2,twenty,5\n3,30,1m\n")
+
+		// act
+		var first, second, third user
+		err1 := d.Decode(&first)
+		err2 := d.Decode(&second)
+		err3 := d.Decode(&third)
+
+		// assert
+		var cellErr *CellError
+		require.ErrorAs(t, err1, &cellErr)
+		require.Equal(t, 2, cellErr.Line)
+		require.Equal(t, "id", cellErr.Column)
+		require.Equal(t, "ID", cellErr.Field)
+		require.Contains(t, err1.Error(), "csvcodec: line 2, column id into field ID: ")
+		require.ErrorContains(t, err2, "column age into field Age")
+		require.ErrorContains(t, err2, "column timeout into field Timeout")
+		require.NoError(t, err3)
+		require.Equal(t, user{ID: 3, Age: optional.Some(30), Timeout: optional.Some(time.Minute)}, third)
+	})
+
+	t.Run("CSV - unknown columns", func(t *testing.T) {
+		// arrange
+		d := decoder("id,unknown\n1,x\n")
+		d.DisallowUnknownColumns = true
+
+		// act
+		var u user
+		err := d.Decode(&u)
+
+		// assert
+		require.ErrorIs(t, err, ErrUnknownColumn)
+		require.Equal(t, 1, u.ID)
+	})
+
+	t.Run("CSV - invalid destination", func(t *testing.T) {
+		// act
+		var u user
+		err := decoder("id\n1\n").Decode(u)
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+
+	t.Run("CSV - inner values with their own Scan", func(t *testing.T) {
+		// arrange
+		type row struct {
+			ID     optional.Option[id] `csv:"id"`
+			Parent nullable.Null[id]   `csv:"parent"`
+		}
+
+		// act
+		var r row
+		err := decoder("id,parent\na1,b2\n").Decode(&r)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, row{ID: optional.Some[id]("a1"), Parent: nullable.Some[id]("b2")}, r)
+	})
+
+	t.Run("CSV - values with Option like methods", func(t *testing.T) {
+		// arrange
+		type row struct {
+			Code code `csv:"code"`
+		}
+
+		// act
+		var r row
+		err := decoder("code\nX1\n").Decode(&r)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, row{Code: "X1"}, r)
+	})
+}
+
+// Tests for Decoder DecodeAll
+func TestDecoder_DecodeAll(t *testing.T) {
+	t.Run("CSV - slice of structs", func(t *testing.T) {
+		// act
+		var users []user
+		err := decoder("id,age\n1,20\n2,\n").DecodeAll(&users)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []user{{ID: 1, Age: optional.Some(20)}, {ID: 2, Age: optional.None[int]()}}, users)
+	})
+
+	t.Run("CSV - slice of pointers", func(t *testing.T) {
+		// act
+		var users []*user
+		err := decoder("id\n1\n2\n").DecodeAll(&users)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, 2, users[1].ID)
+	})
+
+	t.Run("CSV - only header", func(t *testing.T) {
+		// act
+		var users []user
+		err := decoder("id\n").DecodeAll(&users)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+}
+
+// Tests for the null sentinels of the fields
+func TestDecoder_Sentinels(t *testing.T) {
+	type legacy struct {
+		Age   optional.Option[int]  `csv:"age" optional:"null=-1,null=N/A"`
+		Birth nullable.Null[string] `csv:"birth" optional:"null=0000-00-00"`
+	}
+
+	t.Run("CSV - sentinels are null cells", func(t *testing.T) {
+		// act
+		var rows []legacy
+		err := decoder("age,birth\nN/A,0000-00-00\n-1,\n20,2000-01-01\n").DecodeAll(&rows)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []legacy{
+			{Age: optional.None[int](), Birth: nullable.None[string]()},
+			{Age: optional.None[int](), Birth: nullable.None[string]()},
+			{Age: optional.Some(20), Birth: nullable.Some("2000-01-01")},
+		}, rows)
+	})
+
+	t.Run("CSV - first sentinel is the null cell when encoding", func(t *testing.T) {
+		// arrange
+		var buf strings.Builder
+		e := NewEncoder(csv.NewWriter(&buf))
+		e.ColumnNulls = map[string]string{"birth": ""}
+
+		// act
+		err := e.EncodeAll([]legacy{{}})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "age,birth\n-1,\n", buf.String())
+	})
+}
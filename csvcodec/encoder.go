@@ -0,0 +1,120 @@
+package csvcodec
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textfield"
+)
+
+// Encoder writes structs as csv records, one record per struct.
+// The first call to Encode writes the header, from the fields of the struct.
+type Encoder struct {
+	// Null is the cell of None (Option), Null (Null) and nil (pointers) fields. It is "" by default.
+	Null string
+	// ColumnNulls are the null cells of some columns by header name, replacing Null for them.
+	ColumnNulls map[string]string
+
+	w       *csv.Writer
+	columns []column
+}
+
+// NewEncoder returns an Encoder writing to w.
+// The records are buffered by w, so Flush must be called after the last Encode.
+func NewEncoder(w *csv.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the struct src (or pointed by src) as a record, after the header in the first call.
+// All the structs must be of the same type.
+//
+// All the fields are encoded before returning, so the returned error joins
+// a *CellError for each field that can not be encoded (no record is written then).
+func (e *Encoder) Encode(src any) (err error) {
+	rv := reflect.ValueOf(src)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: expected a struct, got %T", ErrInvalidValue, src)
+		return
+	}
+	rv = structs.Addressable(rv)
+
+	if e.columns == nil {
+		e.columns = columnsOf(rv.Type())
+		header := make([]string, len(e.columns))
+		for i, c := range e.columns {
+			header[i] = c.name
+		}
+		if err = e.w.Write(header); err != nil {
+			return
+		}
+	}
+
+	record := make([]string, len(e.columns))
+	var errs []error
+	for i, c := range e.columns {
+		if record[i], err = e.encodeCell(e.null(c), rv.FieldByIndex(c.field.Index)); err != nil {
+			errs = append(errs, &CellError{Column: c.name, Field: c.field.StructField.Name, Err: err})
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return
+	}
+	err = e.w.Write(record)
+	return
+}
+
+// EncodeAll writes the elements of the slice src as records and flushes the writer.
+// The elements of the slice can be structs or pointers to structs.
+func (e *Encoder) EncodeAll(src any) (err error) {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		err = fmt.Errorf("%w: expected a slice of structs, got %T", ErrInvalidValue, src)
+		return
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err = e.Encode(rv.Index(i).Interface()); err != nil {
+			return
+		}
+	}
+	err = e.Flush()
+	return
+}
+
+// Flush writes the buffered records to the underlying writer and returns its error, if any.
+func (e *Encoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// null returns the null cell of the column: the null of the column, the first sentinel of its field
+// or the null of all the columns.
+func (e *Encoder) null(c column) string {
+	if null, ok := e.ColumnNulls[c.name]; ok {
+		return null
+	}
+	if nulls := sentinel.Nulls(c.field.StructField); len(nulls) > 0 {
+		return nulls[0]
+	}
+	return e.Null
+}
+
+// encodeCell returns the cell of the field value fv, or null if it has no value.
+func (e *Encoder) encodeCell(null string, fv reflect.Value) (cell string, err error) {
+	texts, isNull, err := textfield.Encode(fv)
+	switch {
+	case err != nil:
+		return
+	case isNull:
+		return null, nil
+	case len(texts) != 1:
+		return "", fmt.Errorf("%w: a cell can not hold %d values", ErrInvalidValue, len(texts))
+	}
+	return texts[0], nil
+}
@@ -0,0 +1,99 @@
+package csvcodec
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Encoder EncodeAll
+func TestEncoder_EncodeAll(t *testing.T) {
+	score := 9.5
+	users := []user{
+		{ID: 1, Name: optional.Some("Mary"), Age: optional.Some(20), Nickname: nullable.Some("mary"), Timeout: optional.Some(5 * time.Second), Score: &score, Comment: "a, b"},
+		{ID: 2},
+	}
+
+	t.Run("CSV - values and nulls", func(t *testing.T) {
+		// arrange
+		var buf strings.Builder
+		e := NewEncoder(csv.NewWriter(&buf))
+
+		// act
+		err := e.EncodeAll(users)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "id,name,age,nickname,timeout,score,Comment\n"+
+			"1,Mary,20,mary,5s,9.5,\"a, b\"\n"+
+			"2,,,,,,\n", buf.String())
+	})
+
+	t.Run("CSV - null cells", func(t *testing.T) {
+		// arrange
+		var buf strings.Builder
+		e := NewEncoder(csv.NewWriter(&buf))
+		e.Null = `\N`
+		e.ColumnNulls = map[string]string{"nickname": "NULL"}
+
+		// act
+		err := e.Encode(&users[1])
+		require.NoError(t, err)
+		err = e.Flush()
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "id,name,age,nickname,timeout,score,Comment\n"+
+			"2,\\N,\\N,NULL,\\N,\\N,\n", buf.String())
+	})
+
+	t.Run("CSV - round trip", func(t *testing.T) {
+		// arrange
+		var buf strings.Builder
+		err := NewEncoder(csv.NewWriter(&buf)).EncodeAll(users)
+		require.NoError(t, err)
+
+		// act
+		var decoded []user
+		err = decoder(buf.String()).DecodeAll(&decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, users[0], decoded[0])
+		require.False(t, decoded[1].Name.IsSome())
+	})
+
+	t.Run("CSV - unsupported field", func(t *testing.T) {
+		// arrange
+		type invalid struct {
+			Attrs optional.Option[map[string]int] `csv:"attrs"`
+			Tags  optional.Option[[]string]       `csv:"tags"`
+		}
+		var buf strings.Builder
+		e := NewEncoder(csv.NewWriter(&buf))
+
+		// act
+		err := e.Encode(invalid{Attrs: optional.Some(map[string]int{}), Tags: optional.Some([]string{"a", "b"})})
+
+		// assert
+		var cellErr *CellError
+		require.ErrorAs(t, err, &cellErr)
+		require.Equal(t, "attrs", cellErr.Column)
+		require.ErrorIs(t, err, ErrInvalidValue)
+		require.EqualError(t, err, "csvcodec: column attrs into field Attrs: textconv: unsupported type map[string]int\n"+
+			"csvcodec: column tags into field Tags: csvcodec: invalid value: a cell can not hold 2 values")
+	})
+
+	t.Run("CSV - invalid source", func(t *testing.T) {
+		// act
+		err := NewEncoder(csv.NewWriter(&strings.Builder{})).Encode(1)
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidValue)
+	})
+}
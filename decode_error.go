@@ -0,0 +1,59 @@
+package optional
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+// DecodeError is the error returned when the inner value of an Option (or a nullable.Null) can not be decoded.
+// It has the format, the expected Go type and the raw value, and can be recovered with errors.As
+// from the errors of json.Unmarshal and bson.Unmarshal.
+//
+// The Path of the value is only known by the decoders that walk the document:
+// - UnmarshalJSON and UnmarshalBSON of this package (json.Unmarshal does not give the path to the Options)
+// - bson.Unmarshal wraps the error in a bsoncodec.DecodeError with the keys of the value
+type DecodeError = decodeerr.Error
+
+// UnmarshalJSON unmarshals data into v as json.Unmarshal, but the *DecodeError of the Options
+// also have the path of the value (e.g. "user.tags[1].age"), and the values equal to the
+// null sentinels of their fields are decoded as None/Null (see MarshalJSON).
+func UnmarshalJSON(data []byte, v any) (err error) {
+	var d jsonDecoder
+	err = d.unmarshalDocument(data, v)
+	return
+}
+
+// UnmarshalBSON unmarshals data into v as bson.Unmarshal, but the *DecodeError of the Options
+// also have the path of the value, with the dot notation of mongodb (e.g. "user.tags.1.age"),
+// and the values equal to the null sentinels of their fields are decoded as None/Null (see MarshalJSON).
+// The Option and nullable.Null codecs are registered (see RegisterBSONCodecs).
+func UnmarshalBSON(data []byte, v any) (err error) {
+	if v != nil {
+		data = sentinel.BSON(data, reflect.TypeOf(v), false)
+	}
+	err = bson.UnmarshalWithRegistry(bsonRegistry(), data, v)
+	setBSONPath(err)
+	return
+}
+
+// setBSONPath sets the path of the *DecodeError in err from the keys of the wrapping bsoncodec.DecodeErrors.
+func setBSONPath(err error) {
+	var keys []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch e := err.(type) {
+		case *bsoncodec.DecodeError:
+			keys = append(keys, e.Keys()...)
+		case *DecodeError:
+			if e.Path == "" {
+				e.Path = strings.Join(keys, ".")
+			}
+			return
+		}
+	}
+}
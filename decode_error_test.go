@@ -0,0 +1,208 @@
+package optional
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type decodeAddress struct {
+	City Option[string] `json:"city" bson:"city"`
+	Zip  Option[int]    `json:"zip" bson:"zip"`
+}
+
+type decodeSchema struct {
+	Age     Option[int]           `json:"age" bson:"age"`
+	Code    StringOption[int64]   `json:"code" bson:"-"`
+	Address decodeAddress         `json:"address" bson:"address"`
+	Others  []decodeAddress       `json:"others" bson:"others"`
+	Inner   Option[decodeAddress] `json:"inner" bson:"inner"`
+}
+
+// Tests for the DecodeError of json decoding
+func TestDecodeError_JSON(t *testing.T) {
+	t.Run("JSON - recovered from json.Unmarshal", func(t *testing.T) {
+		// act
+		var s decodeSchema
+		err := json.Unmarshal([]byte(`{"address": {"zip": "n/a"}}`), &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "json", decodeErr.Format)
+		require.Equal(t, "", decodeErr.Path)
+		require.Equal(t, reflect.TypeOf(0), decodeErr.Type)
+		require.Equal(t, `"n/a"`, decodeErr.Raw)
+		var typeErr *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeErr)
+		require.EqualError(t, err, `optional: cannot decode json "n/a" into int: `+typeErr.Error())
+	})
+
+	t.Run("JSON - string option", func(t *testing.T) {
+		// act
+		var s decodeSchema
+		err := json.Unmarshal([]byte(`{"code": "12a"}`), &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, reflect.TypeOf(int64(0)), decodeErr.Type)
+		require.Equal(t, `"12a"`, decodeErr.Raw)
+	})
+
+	// paths
+	type testCase struct {
+		title string
+		data  string
+		path  string
+		raw   string
+	}
+	cases := []testCase{
+		{title: "JSON - path of a field", data: `{"age": true}`, path: "age", raw: `true`},
+		{title: "JSON - path of a nested field", data: `{"address": {"city": 1}}`, path: "address.city", raw: `1`},
+		{title: "JSON - path of an element", data: `{"others": [{}, {"zip": "x"}]}`, path: "others[1].zip", raw: `"x"`},
+		{title: "JSON - path inside an option", data: `{"inner": {"zip": [1]}}`, path: "inner.zip", raw: `[1]`},
+		{title: "JSON - path of an option", data: `{"inner": 1}`, path: "inner", raw: `1`},
+	}
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			var s decodeSchema
+			err := UnmarshalJSON([]byte(c.data), &s)
+
+			// assert
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			require.Equal(t, c.path, decodeErr.Path)
+			require.Equal(t, c.raw, decodeErr.Raw)
+			require.Contains(t, err.Error(), " at "+c.path+": ")
+		})
+	}
+
+	t.Run("JSON - same output as json.Unmarshal", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"age": 1, "code": "2", "address": {"city": "Lima"}, "others": [{"zip": 3}], "inner": null}`)
+		var expected decodeSchema
+		err := json.Unmarshal(data, &expected)
+		require.NoError(t, err)
+
+		// act
+		var s decodeSchema
+		err = UnmarshalJSON(data, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, expected, s)
+	})
+
+	t.Run("JSON - same output as json.Unmarshal with embedded pointers to structs", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"age": 3, "city": "Lima"}`)
+		var expected lenientEmbedding
+		err := json.Unmarshal(data, &expected)
+		require.NoError(t, err)
+
+		// act
+		var e lenientEmbedding
+		err = UnmarshalJSON(data, &e)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, expected, e)
+		require.Equal(t, 3, e.Age.Unwrap())
+	})
+
+	t.Run("JSON - path of a promoted field", func(t *testing.T) {
+		// act
+		var e lenientEmbedding
+		err := UnmarshalJSON([]byte(`{"age": "three"}`), &e)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "age", decodeErr.Path)
+	})
+
+	t.Run("JSON - long raw values are truncated in the message", func(t *testing.T) {
+		// act
+		var s decodeSchema
+		err := json.Unmarshal([]byte(`{"age": "`+strings.Repeat("x", 100)+`"}`), &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Len(t, decodeErr.Raw, 102)
+		require.Contains(t, err.Error(), `"`+strings.Repeat("x", 63)+"... into int")
+	})
+}
+
+// Tests for the DecodeError of bson decoding
+func TestDecodeError_BSON(t *testing.T) {
+	t.Run("BSON - recovered from bson.Unmarshal", func(t *testing.T) {
+		// arrange
+		data, err := bson.Marshal(bson.M{"address": bson.M{"zip": "n/a"}})
+		require.NoError(t, err)
+
+		// act
+		var s decodeSchema
+		err = bson.Unmarshal(data, &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "bson", decodeErr.Format)
+		require.Equal(t, reflect.TypeOf(0), decodeErr.Type)
+		require.Equal(t, `"n/a"`, decodeErr.Raw)
+	})
+
+	// paths
+	type testCase struct {
+		title string
+		doc   any
+		path  string
+	}
+	cases := []testCase{
+		{title: "BSON - path of a field", doc: bson.M{"age": "x"}, path: "age"},
+		{title: "BSON - path of a nested field", doc: bson.M{"address": bson.M{"city": 1}}, path: "address.city"},
+		{title: "BSON - path of an element", doc: bson.M{"others": bson.A{bson.M{}, bson.M{"zip": "x"}}}, path: "others.1.zip"},
+		{title: "BSON - path inside an option", doc: bson.M{"inner": bson.M{"zip": "x"}}, path: "inner.zip"},
+		{title: "BSON - path of an option", doc: bson.M{"inner": 1}, path: "inner"},
+	}
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// arrange
+			data, err := bson.Marshal(c.doc)
+			require.NoError(t, err)
+
+			// act
+			var s decodeSchema
+			err = UnmarshalBSON(data, &s)
+
+			// assert
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			require.Equal(t, c.path, decodeErr.Path)
+		})
+	}
+
+	t.Run("BSON - registered codecs", func(t *testing.T) {
+		// arrange
+		registry := RegisterBSONCodecs(bson.NewRegistryBuilder()).Build()
+		data, err := bson.Marshal(bson.M{"age": "n/a"})
+		require.NoError(t, err)
+
+		// act
+		var s decodeSchema
+		err = bson.UnmarshalWithRegistry(registry, data, &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, reflect.TypeOf(0), decodeErr.Type)
+		require.Equal(t, `"n/a"`, decodeErr.Raw)
+	})
+}
@@ -0,0 +1,206 @@
+// Package envconfig loads the configuration of a service from environment variables into structs
+// with optional.Option and nullable.Null fields, so an unset variable (None) is told apart from an
+// empty one (Some("")).
+//
+// Variables are mapped to fields with the `env` tag, fields without it are not loaded:
+//
+//	type Config struct {
+//		Port    optional.Option[int]           `env:"PORT" default:"8080"`
+//		Token   string                         `env:"TOKEN" required:"true"`
+//		Hosts   []string                       `env:"HOSTS" separator:";"`
+//		Timeout optional.Option[time.Duration] `env:"TIMEOUT"`
+//		DB      DBConfig                       `env:"DB"` // DB_HOST, DB_USER, ...
+//	}
+//
+// - unset variables are loaded as None (Option), Null (Null) or nil (pointers), other fields are not touched
+// - `default` is the value of an unset variable, `required:"true"` fails if the variable is unset
+// - values are decoded with the encoding.TextUnmarshaler of the value or strconv for the builtin kinds
+// - slices are split by the separator of the Loader (or the `separator` tag), an empty value is an empty slice
+// - struct fields are nested, with their tag and "_" as prefix of their variables
+// - nil pointers to structs are only allocated if any of their variables is set, and pointers to the
+// struct types being loaded (recursive types) are skipped
+// - values equal to a null sentinel of the field (`optional:"null="`, see optional.MarshalJSON) are loaded as unset
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textfield"
+)
+
+var (
+	ErrInvalidDestination = errors.New("envconfig: invalid destination")
+	ErrRequired           = errors.New("envconfig: required variable is not set")
+)
+
+// VarError is the error of a variable that could not be loaded.
+type VarError struct {
+	// Name is the name of the variable, with its prefix.
+	Name string
+	// Field is the path of the struct field (e.g. "DB.Host").
+	Field string
+	// Err is the cause of the error.
+	Err error
+}
+
+// Error returns the message of the error.
+func (e *VarError) Error() string {
+	return fmt.Sprintf("envconfig: variable %s into field %s: %v", e.Name, e.Field, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *VarError) Unwrap() error {
+	return e.Err
+}
+
+// Loader loads environment variables into structs.
+type Loader struct {
+	// Prefix is the prefix of all the variables (e.g. "APP_").
+	Prefix string
+	// Separator splits the values of slices. It is "," if empty.
+	Separator string
+	// LookupEnv returns the value of a variable and whether it is set. It is os.LookupEnv if nil.
+	LookupEnv func(name string) (string, bool)
+}
+
+// Load loads the environment variables into the struct pointed by dst, with the default Loader.
+func Load(dst any) error {
+	var l Loader
+	return l.Load(dst)
+}
+
+// Load loads the environment variables into the struct pointed by dst.
+//
+// All the variables are loaded before returning, so the returned error joins a *VarError
+// for each missing or invalid variable.
+func (l *Loader) Load(dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a pointer to a struct, got %T", ErrInvalidDestination, dst)
+	}
+
+	var errs []error
+	l.load(rv.Elem(), l.Prefix, "", &errs, make(map[reflect.Type]bool))
+	return errors.Join(errs...)
+}
+
+// load loads the variables with the prefix into the fields of the struct value sv, whose path is path,
+// and returns true if any variable was set. visited has the struct types being loaded, so recursive
+// types are not walked again.
+func (l *Loader) load(sv reflect.Value, prefix, path string, errs *[]error, visited map[reflect.Type]bool) (set bool) {
+	visited[sv.Type()] = true
+	defer delete(visited, sv.Type())
+
+	for _, f := range structs.Fields(sv.Type(), "env") {
+		fv := sv.FieldByIndex(f.Index)
+		field := path + f.StructField.Name
+
+		// nested structs
+		if isNested(fv.Type()) {
+			nested := prefix
+			if f.Name != "" {
+				nested += f.Name + "_"
+			}
+			set = l.loadNested(fv, nested, field+".", errs, visited) || set
+			continue
+		}
+		if f.Name == "" {
+			continue
+		}
+
+		name := prefix + f.Name
+		varSet, err := l.loadVar(fv, f.StructField, name)
+		if err != nil {
+			*errs = append(*errs, &VarError{Name: name, Field: field, Err: err})
+		}
+		set = set || varSet
+	}
+	return
+}
+
+// loadNested loads the nested struct (or pointer to struct) value fv and returns true if any variable was set.
+// A nil pointer is only allocated if any of its variables is set, so its errors (e.g. required variables)
+// are only returned in that case. The pointers to the struct types being loaded are skipped.
+func (l *Loader) loadNested(fv reflect.Value, prefix, path string, errs *[]error, visited map[reflect.Type]bool) bool {
+	if fv.Kind() != reflect.Pointer {
+		return l.load(fv, prefix, path, errs, visited)
+	}
+	if visited[fv.Type().Elem()] {
+		return false
+	}
+	if !fv.IsNil() {
+		return l.load(fv.Elem(), prefix, path, errs, visited)
+	}
+
+	v := reflect.New(fv.Type().Elem())
+	var nestedErrs []error
+	if !l.load(v.Elem(), prefix, path, &nestedErrs, visited) {
+		return false
+	}
+	fv.Set(v)
+	*errs = append(*errs, nestedErrs...)
+	return true
+}
+
+// isNested returns true if the fields of values of type t are loaded as a nested config:
+// structs and pointers to structs, other than leaf values (Option, Null, time.Time, ...).
+func isNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !structs.IsLeaf(t)
+}
+
+// loadVar loads the variable name into the field value fv of the struct field sf,
+// and returns true if the variable is set.
+func (l *Loader) loadVar(fv reflect.Value, sf reflect.StructField, name string) (set bool, err error) {
+	lookup := l.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	value, ok := lookup(name)
+	set = ok
+	if !ok {
+		value, ok = sf.Tag.Lookup("default")
+	}
+	if !ok && sf.Tag.Get("required") == "true" {
+		err = ErrRequired
+		return
+	}
+	if ok && slices.Contains(sentinel.Nulls(sf), value) {
+		ok = false
+	}
+	if !ok && !structs.IsOptional(fv.Type()) && fv.Kind() != reflect.Pointer {
+		return
+	}
+
+	texts := []string{value}
+	if textfield.IsList(fv.Type()) {
+		texts = l.split(value, sf)
+	}
+	err = textfield.Decode(fv, texts, !ok)
+	return
+}
+
+// split returns the elements of the value of a slice field sf.
+func (l *Loader) split(value string, sf reflect.StructField) []string {
+	if value == "" {
+		return nil
+	}
+	separator, ok := sf.Tag.Lookup("separator")
+	if !ok {
+		separator = l.Separator
+	}
+	if separator == "" {
+		separator = ","
+	}
+	return strings.Split(value, separator)
+}
@@ -0,0 +1,246 @@
+package envconfig
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+type dbConfig struct {
+	Host optional.Option[string] `env:"HOST" default:"localhost"`
+	User string                  `env:"USER" required:"true"`
+}
+
+type config struct {
+	Port     optional.Option[int]           `env:"PORT" default:"8080"`
+	Name     optional.Option[string]        `env:"NAME"`
+	Token    string                         `env:"TOKEN" required:"true"`
+	Hosts    optional.Option[[]string]      `env:"HOSTS"`
+	Ports    []int                          `env:"PORTS" separator:";"`
+	Timeout  optional.Option[time.Duration] `env:"TIMEOUT"`
+	Addr     optional.Option[netip.Addr]    `env:"ADDR"`
+	Region   nullable.Null[string]          `env:"REGION" optional:"null="`
+	Debug    *bool                          `env:"DEBUG"`
+	DB       dbConfig                       `env:"DB"`
+	Internal string
+}
+
+// id is a value with its own sql.Scanner, as uuid or decimal types.
+type id string
+
+func (i *id) Scan(src any) error {
+	*i = id(fmt.Sprint(src))
+	return nil
+}
+
+// Tests for Load
+func TestLoad(t *testing.T) {
+	t.Run("Env - values", func(t *testing.T) {
+		// arrange
+		t.Setenv("PORT", "9090")
+		t.Setenv("NAME", "api")
+		t.Setenv("TOKEN", "secret")
+		t.Setenv("HOSTS", "a,b")
+		t.Setenv("PORTS", "1;2")
+		t.Setenv("TIMEOUT", "5s")
+		t.Setenv("ADDR", "10.0.0.1")
+		t.Setenv("REGION", "eu")
+		t.Setenv("DEBUG", "true")
+		t.Setenv("DB_HOST", "db")
+		t.Setenv("DB_USER", "admin")
+
+		// act
+		var c config
+		err := Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		debug := true
+		require.Equal(t, config{
+			Port:    optional.Some(9090),
+			Name:    optional.Some("api"),
+			Token:   "secret",
+			Hosts:   optional.Some([]string{"a", "b"}),
+			Ports:   []int{1, 2},
+			Timeout: optional.Some(5 * time.Second),
+			Addr:    optional.Some(netip.MustParseAddr("10.0.0.1")),
+			Region:  nullable.Some("eu"),
+			Debug:   &debug,
+			DB:      dbConfig{Host: optional.Some("db"), User: "admin"},
+		}, c)
+	})
+
+	t.Run("Env - unset and empty variables", func(t *testing.T) {
+		// arrange
+		t.Setenv("TOKEN", "secret")
+		t.Setenv("DB_USER", "admin")
+		t.Setenv("NAME", "")
+		t.Setenv("HOSTS", "")
+		t.Setenv("REGION", "")
+
+		// act
+		c := config{Internal: "kept", Timeout: optional.Some(time.Second)}
+		err := Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 8080, c.Port.Unwrap())
+		require.Equal(t, "", c.Name.Unwrap())
+		require.Equal(t, []string{}, c.Hosts.Unwrap())
+		require.False(t, c.Timeout.IsSome())
+		require.True(t, c.Region.IsNull())
+		require.Nil(t, c.Debug)
+		require.Equal(t, "localhost", c.DB.Host.Unwrap())
+		require.Equal(t, "kept", c.Internal)
+	})
+
+	t.Run("Env - prefix and lookup", func(t *testing.T) {
+		// arrange
+		env := map[string]string{"APP_TOKEN": "secret", "APP_DB_USER": "admin", "APP_HOSTS": "a|b"}
+		l := Loader{Prefix: "APP_", Separator: "|", LookupEnv: func(name string) (string, bool) {
+			v, ok := env[name]
+			return v, ok
+		}}
+
+		// act
+		var c config
+		err := l.Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "secret", c.Token)
+		require.Equal(t, "admin", c.DB.User)
+		require.Equal(t, []string{"a", "b"}, c.Hosts.Unwrap())
+	})
+
+	t.Run("Env - wrappers of Option", func(t *testing.T) {
+		// arrange
+		type wrappers struct {
+			ID     optional.StringOption[int]    `env:"ID"`
+			Strict optional.StrictOption[int]    `env:"STRICT"`
+			Token  optional.SecretOption[string] `env:"TOKEN" optional:"null=-"`
+		}
+		t.Setenv("ID", "3")
+		t.Setenv("TOKEN", "-")
+
+		// act
+		c := wrappers{Strict: optional.SomeStrict(2), Token: optional.SomeSecret("abc")}
+		err := Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, wrappers{ID: optional.SomeString(3), Strict: optional.NoneStrict[int](), Token: optional.NoneSecret[string]()}, c)
+	})
+
+	t.Run("Env - inner values with their own Scan", func(t *testing.T) {
+		// arrange
+		type ids struct {
+			ID     optional.Option[id] `env:"ID"`
+			Parent nullable.Null[id]   `env:"PARENT"`
+		}
+		t.Setenv("ID", "a1")
+		t.Setenv("PARENT", "b2")
+
+		// act
+		var c ids
+		err := Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, ids{ID: optional.Some[id]("a1"), Parent: nullable.Some[id]("b2")}, c)
+	})
+
+	t.Run("Env - nested pointers to structs", func(t *testing.T) {
+		// arrange
+		type app struct {
+			DB      *dbConfig `env:"DB"`
+			Replica *dbConfig `env:"REPLICA"`
+		}
+		t.Setenv("DB_USER", "admin")
+		t.Setenv("REPLICA_USER", "reader")
+		replica := &dbConfig{Host: optional.Some("replica")}
+
+		// act
+		c := app{Replica: replica}
+		err := Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, &dbConfig{Host: optional.Some("localhost"), User: "admin"}, c.DB)
+		require.Same(t, replica, c.Replica)
+		require.Equal(t, &dbConfig{Host: optional.Some("localhost"), User: "reader"}, c.Replica)
+	})
+
+	t.Run("Env - nested pointers without variables stay nil", func(t *testing.T) {
+		// arrange
+		type tlsConfig struct {
+			Cert optional.Option[string] `env:"CERT" default:"cert.pem"`
+			Key  string                  `env:"KEY" required:"true"`
+		}
+		type app struct {
+			TLS *tlsConfig `env:"TLS"`
+		}
+
+		// act
+		var c app
+		err := Load(&c)
+
+		// assert
+		require.NoError(t, err)
+		require.Nil(t, c.TLS)
+	})
+
+	t.Run("Env - recursive types", func(t *testing.T) {
+		// arrange
+		type node struct {
+			Name   optional.Option[string] `env:"NAME"`
+			Parent *node                   `env:"PARENT"`
+		}
+		t.Setenv("NAME", "child")
+		t.Setenv("PARENT_NAME", "parent")
+
+		// act
+		var n node
+		err := Load(&n)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, node{Name: optional.Some("child")}, n)
+	})
+
+	// errors
+	t.Run("Env - missing and invalid variables", func(t *testing.T) {
+		// arrange
+		t.Setenv("PORT", "http")
+		t.Setenv("TIMEOUT", "5")
+
+		// act
+		var c config
+		err := Load(&c)
+
+		// assert
+		var varErr *VarError
+		require.ErrorAs(t, err, &varErr)
+		require.Equal(t, "PORT", varErr.Name)
+		require.Equal(t, "Port", varErr.Field)
+		require.ErrorIs(t, err, ErrRequired)
+		require.ErrorContains(t, err, "envconfig: variable PORT into field Port: ")
+		require.ErrorContains(t, err, "envconfig: variable TOKEN into field Token: envconfig: required variable is not set")
+		require.ErrorContains(t, err, "envconfig: variable TIMEOUT into field Timeout: ")
+		require.ErrorContains(t, err, "envconfig: variable DB_USER into field DB.User: envconfig: required variable is not set")
+	})
+
+	t.Run("Env - invalid destination", func(t *testing.T) {
+		// act
+		var c config
+		err := Load(c)
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidDestination)
+	})
+}
@@ -0,0 +1,40 @@
+package optional
+
+import (
+	"flag"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/textconv"
+)
+
+// FlagVar defines a flag with the given name and usage in fs (flag.CommandLine if nil), and returns
+// the Option that stores its value: it stays None if the flag is not passed, so the flag can be layered
+// over other sources of configuration (e.g. a config file or environment variables).
+func FlagVar[T any](fs *flag.FlagSet, name, usage string) *Option[T] {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	o := new(Option[T])
+	fs.Var(o, name, usage)
+	return o
+}
+
+// Set implements flag.Value: the Option is set to Some with the value decoded from s, with its
+// encoding.TextUnmarshaler or strconv for the builtin kinds (errors as *DecodeError).
+// Unlike UnmarshalText, an empty s is Some (e.g. Some("") for an Option[string]).
+func (o *Option[T]) Set(s string) (err error) {
+	var v T
+	if err = textconv.Unmarshal(s, &v); err != nil {
+		err = decodeerr.New("flag", o.innerType(), s, err)
+		return
+	}
+	o.value = &v
+	return
+}
+
+// IsBoolFlag implements the optional method of flag.Value for boolean flags,
+// so an Option[bool] flag can be passed without value (-verbose).
+func (o *Option[T]) IsBoolFlag() bool {
+	return o.innerType().Kind() == reflect.Bool
+}
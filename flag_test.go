@@ -0,0 +1,98 @@
+package optional
+
+import (
+	"flag"
+	"io"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for FlagVar
+func TestFlagVar(t *testing.T) {
+	newFlagSet := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		return fs
+	}
+
+	t.Run("Flag - passed and unset flags", func(t *testing.T) {
+		// arrange
+		fs := newFlagSet()
+		port := FlagVar[int](fs, "port", "port to listen on")
+		name := FlagVar[string](fs, "name", "name of the service")
+		timeout := FlagVar[time.Duration](fs, "timeout", "request timeout")
+		addr := FlagVar[netip.Addr](fs, "addr", "address to bind")
+		host := FlagVar[string](fs, "host", "host of the database")
+
+		// act
+		err := fs.Parse([]string{"-port", "8080", "-name=", "-timeout=5s", "-addr", "10.0.0.1"})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 8080, port.Unwrap())
+		require.Equal(t, "", name.Unwrap())
+		require.Equal(t, 5*time.Second, timeout.Unwrap())
+		require.Equal(t, netip.MustParseAddr("10.0.0.1"), addr.Unwrap())
+		require.False(t, host.IsSome())
+	})
+
+	t.Run("Flag - boolean flags", func(t *testing.T) {
+		// arrange
+		fs := newFlagSet()
+		verbose := FlagVar[bool](fs, "verbose", "verbose output")
+		dryRun := FlagVar[bool](fs, "dry-run", "do not apply the changes")
+		color := FlagVar[bool](fs, "color", "colored output")
+
+		// act
+		err := fs.Parse([]string{"-verbose", "-dry-run=false"})
+
+		// assert
+		require.NoError(t, err)
+		require.True(t, verbose.Unwrap())
+		require.False(t, dryRun.Unwrap())
+		require.False(t, color.IsSome())
+	})
+
+	t.Run("Flag - usage", func(t *testing.T) {
+		// arrange
+		fs := newFlagSet()
+		var out strings.Builder
+		fs.SetOutput(&out)
+		FlagVar[int](fs, "port", "port to listen on")
+
+		// act
+		fs.PrintDefaults()
+
+		// assert
+		require.Equal(t, "  -port value\n    \tport to listen on\n", out.String())
+	})
+
+	// errors
+	t.Run("Flag - invalid value", func(t *testing.T) {
+		// arrange
+		fs := newFlagSet()
+		FlagVar[int](fs, "port", "port to listen on")
+
+		// act
+		err := fs.Parse([]string{"-port", "http"})
+
+		// assert
+		require.ErrorContains(t, err, `invalid value "http" for flag -port: optional: cannot decode flag http into int: `)
+	})
+
+	t.Run("Flag - direct call", func(t *testing.T) {
+		// act
+		var o Option[uint8]
+		err := o.Set("300")
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "flag", decodeErr.Format)
+		require.False(t, o.IsSome())
+	})
+}
@@ -0,0 +1,35 @@
+package optional
+
+import (
+	"fmt"
+
+	"github.com/LNMMusic/optional/internal/fmtconv"
+)
+
+// String returns "Some(<value>)" or "None", with the inner value formatted as %v.
+func (o Option[T]) String() string {
+	if o.value == nil {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", *o.value)
+}
+
+// GoString returns the Go syntax of the Option: "optional.Some[T](<value>)" or "optional.None[T]()",
+// with the inner value formatted as %#v.
+func (o Option[T]) GoString() string {
+	if o.value == nil {
+		return fmt.Sprintf("optional.None[%s]()", o.innerType())
+	}
+	return fmt.Sprintf("optional.Some[%s](%#v)", o.innerType(), *o.value)
+}
+
+// Format implements fmt.Formatter:
+// - %v and %s: as String (e.g. "Some(42)"), %#v: as GoString
+// - any other verb (e.g. %5.2f, %x, %q) is passed through to the inner value, None is printed as "None"
+func (o Option[T]) Format(f fmt.State, verb rune) {
+	if o.value == nil {
+		fmtconv.Format(f, verb, o, nil, false)
+		return
+	}
+	fmtconv.Format(f, verb, o, *o.value, true)
+}
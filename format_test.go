@@ -0,0 +1,63 @@
+package optional
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Option String, GoString and Format
+func TestOption_Format(t *testing.T) {
+	type input struct {
+		format string
+		value  any
+	}
+	type output struct{ text string }
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// String
+		{title: "Format - %v some", input: input{format: "%v", value: Some(42)}, output: output{text: "Some(42)"}},
+		{title: "Format - %v none", input: input{format: "%v", value: None[int]()}, output: output{text: "None"}},
+		{title: "Format - %s some string", input: input{format: "%s", value: Some("a")}, output: output{text: "Some(a)"}},
+		{title: "Format - %v nested", input: input{format: "%v", value: Some(Some(1))}, output: output{text: "Some(Some(1))"}},
+		{title: "Format - %v pointer", input: input{format: "%v", value: &Option[int]{}}, output: output{text: "None"}},
+		{title: "Format - %10v width", input: input{format: "%10v", value: Some(1)}, output: output{text: "   Some(1)"}},
+		{title: "Format - %v in struct", input: input{format: "%v", value: struct{ A Option[int] }{A: Some(1)}}, output: output{text: "{Some(1)}"}},
+		// GoString
+		{title: "Format - %#v some", input: input{format: "%#v", value: Some(42)}, output: output{text: "optional.Some[int](42)"}},
+		{title: "Format - %#v some string", input: input{format: "%#v", value: Some("a")}, output: output{text: `optional.Some[string]("a")`}},
+		{title: "Format - %#v none", input: input{format: "%#v", value: None[int]()}, output: output{text: "optional.None[int]()"}},
+		// inner verbs
+		{title: "Format - %5.2f some", input: input{format: "%5.2f", value: Some(3.14159)}, output: output{text: " 3.14"}},
+		{title: "Format - %05d some", input: input{format: "%05d", value: Some(42)}, output: output{text: "00042"}},
+		{title: "Format - %x some", input: input{format: "%x", value: Some(255)}, output: output{text: "ff"}},
+		{title: "Format - %q some", input: input{format: "%q", value: Some("a")}, output: output{text: `"a"`}},
+		{title: "Format - %d none", input: input{format: "%d", value: None[int]()}, output: output{text: "None"}},
+		{title: "Format - %-6.2f none", input: input{format: "%-6.2f|", value: None[float64]()}, output: output{text: "None  |"}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			text := fmt.Sprintf(c.input.format, c.input.value)
+
+			// assert
+			require.Equal(t, c.output.text, text)
+		})
+	}
+
+	t.Run("Format - promoted to StringOption", func(t *testing.T) {
+		// act
+		text := fmt.Sprint(SomeString(int64(7)))
+
+		// assert
+		require.Equal(t, "Some(7)", text)
+	})
+}
@@ -5,10 +5,10 @@ go 1.21
 require (
 	github.com/stretchr/testify v1.8.3
 	go.mongodb.org/mongo-driver v1.12.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
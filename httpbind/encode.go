@@ -0,0 +1,69 @@
+package httpbind
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textfield"
+)
+
+// EncodeQuery returns the url query values of the fields of the struct src with the `query` tag (see Encode).
+func EncodeQuery(src any) (url.Values, error) {
+	return Encode(SourceQuery, src)
+}
+
+// EncodeForm returns the form values of the fields of the struct src with the `form` tag (see Encode).
+func EncodeForm(src any) (url.Values, error) {
+	return Encode(SourceForm, src)
+}
+
+// Encode returns the url values of the fields of the struct src (or pointed by src) with the tag of the source.
+// It is the reverse of Bind, so the values are bound back to an equal struct:
+// - None (Option) and nil (pointers) fields are omitted, Null (Null) fields are empty values
+// - None and Null fields with null sentinels are the first sentinel
+// - slices are repeated keys, one per element (an empty slice is omitted)
+// - values are encoded with the encoding.TextMarshaler of the value or strconv for the builtin kinds
+//
+// All the fields are encoded before returning, so the returned error joins a *ParamError for each invalid field.
+func Encode(source string, src any) (values url.Values, err error) {
+	rv := reflect.ValueOf(src)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: expected a struct, got %T", ErrInvalidSource, src)
+		return
+	}
+	rv = structs.Addressable(rv)
+
+	values = url.Values{}
+	var errs []error
+	for _, f := range structs.Fields(rv.Type(), source) {
+		if f.Name == "" {
+			continue
+		}
+		fv := rv.FieldByIndex(f.Index)
+		texts, null, err := textfield.Encode(fv)
+		if err != nil {
+			errs = append(errs, &ParamError{Source: source, Name: f.Name, Field: f.StructField.Name, Err: err})
+			continue
+		}
+		if null {
+			if nulls := sentinel.Nulls(f.StructField); len(nulls) > 0 {
+				values.Add(f.Name, nulls[0])
+			} else if structs.IsNull(fv.Type()) {
+				values.Add(f.Name, "")
+			}
+			continue
+		}
+		for _, text := range texts {
+			values.Add(f.Name, text)
+		}
+	}
+	err = errors.Join(errs...)
+	return
+}
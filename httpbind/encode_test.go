@@ -0,0 +1,151 @@
+package httpbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+type filterParams struct {
+	Page     optional.Option[int]           `query:"page" form:"page"`
+	Tags     optional.Option[[]string]      `query:"tag" form:"tag"`
+	Since    optional.Option[time.Time]     `query:"since" form:"since"`
+	Timeout  optional.Option[time.Duration] `query:"timeout" form:"timeout"`
+	Owner    nullable.Null[int]             `query:"owner" form:"owner"`
+	Status   optional.Option[string]        `query:"status" form:"status" optional:"null=any"`
+	Limit    *int                           `query:"limit" form:"limit"`
+	Search   string                         `query:"q" form:"q"`
+	Internal string
+}
+
+// Tests for EncodeQuery
+func TestEncodeQuery(t *testing.T) {
+	limit := 10
+	type input struct{ src any }
+	type output struct{ query string }
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		{title: "Query - none fields are omitted, or their first sentinel", input: input{src: filterParams{Owner: nullable.Some(1)}}, output: output{query: "owner=1&q=&status=any"}},
+		{title: "Query - null fields are empty", input: input{src: filterParams{Search: "go"}}, output: output{query: "owner=&q=go&status=any"}},
+		{title: "Query - slices are repeated keys", input: input{src: filterParams{Tags: optional.Some([]string{"a", "b"}), Owner: nullable.Some(1)}}, output: output{query: "owner=1&q=&status=any&tag=a&tag=b"}},
+		{title: "Query - some fields with sentinels", input: input{src: filterParams{Status: optional.Some("open"), Owner: nullable.Some(1)}}, output: output{query: "owner=1&q=&status=open"}},
+		{title: "Query - text values", input: input{src: &filterParams{Page: optional.Some(2), Since: optional.Some(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)), Timeout: optional.Some(time.Minute), Owner: nullable.Some(1), Limit: &limit}}, output: output{query: "limit=10&owner=1&page=2&q=&since=2024-01-02T00%3A00%3A00Z&status=any&timeout=1m0s"}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			values, err := EncodeQuery(c.input.src)
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, c.output.query, values.Encode())
+		})
+	}
+
+	t.Run("Query - wrappers of Option", func(t *testing.T) {
+		// arrange
+		type params struct {
+			ID     optional.StringOption[int]    `query:"id"`
+			Strict optional.StrictOption[int]    `query:"strict"`
+			Token  optional.SecretOption[string] `query:"token"`
+			Code   optional.StringOption[int]    `query:"code" optional:"null=-1"`
+		}
+
+		// act
+		some, err1 := EncodeQuery(params{ID: optional.SomeString(1), Strict: optional.SomeStrict(2), Token: optional.SomeSecret("abc")})
+		none, err2 := EncodeQuery(params{})
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Equal(t, "code=-1&id=1&strict=2&token=abc", some.Encode())
+		require.Equal(t, "code=-1", none.Encode())
+	})
+
+	// errors
+	t.Run("Query - unsupported field", func(t *testing.T) {
+		// arrange
+		type params struct {
+			Filter optional.Option[map[string]int] `query:"filter"`
+		}
+
+		// act
+		_, err := EncodeQuery(params{Filter: optional.Some(map[string]int{})})
+
+		// assert
+		var paramErr *ParamError
+		require.ErrorAs(t, err, &paramErr)
+		require.Equal(t, "filter", paramErr.Name)
+	})
+
+	t.Run("Query - invalid source", func(t *testing.T) {
+		// act
+		_, err := EncodeQuery([]int{1})
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidSource)
+	})
+}
+
+// Tests for the round trip of Encode and Request
+func TestEncode_RoundTrip(t *testing.T) {
+	limit := 10
+	cases := []filterParams{
+		{},
+		{Page: optional.Some(2), Tags: optional.Some([]string{"a", "b"}), Owner: nullable.Some(7), Search: "go"},
+		{Since: optional.Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), Timeout: optional.Some(90 * time.Second), Limit: &limit},
+		{Status: optional.Some("open"), Tags: optional.Some([]string{"a, b", "c&d"})},
+	}
+
+	for _, src := range cases {
+		t.Run("Query - round trip", func(t *testing.T) {
+			// arrange
+			values, err := EncodeQuery(src)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodGet, "/?"+values.Encode(), nil)
+
+			// act
+			var dst filterParams
+			err = Request(r, &dst)
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, src, dst)
+		})
+
+		t.Run("Form - round trip", func(t *testing.T) {
+			// arrange
+			values, err := EncodeForm(src)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			// act
+			var dst filterParams
+			err = Form(mustParseForm(t, r), &dst)
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, src, dst)
+		})
+	}
+}
+
+func mustParseForm(t *testing.T, r *http.Request) url.Values {
+	require.NoError(t, r.ParseForm())
+	return r.PostForm
+}
@@ -0,0 +1,235 @@
+// Package httpbind binds the parameters of http requests (query, form, header and path params)
+// to structs with optional.Option and nullable.Null fields.
+//
+// Params are mapped to fields by the tag of their source: `query:"page"`, `form:"name"`,
+// `header:"X-Trace"` or `path:"id"`. Fields without the tag of the source are not touched.
+// - absent params are bound as None (Option), Null (Null) or nil (pointers), other fields are not touched
+// - empty params are bound as Null for Null fields
+// - values are decoded with the encoding.TextUnmarshaler of the value or strconv for the builtin kinds
+// - repeated params are bound to slices (e.g. Option[[]int]), other fields take the first value
+// - values equal to a null sentinel of the field (`optional:"null="`, see optional.MarshalJSON) are bound as absent
+package httpbind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textfield"
+)
+
+var (
+	ErrInvalidDestination = errors.New("httpbind: invalid destination")
+	ErrInvalidSource      = errors.New("httpbind: invalid source")
+)
+
+// sources of the params, which are also their tags
+const (
+	SourceQuery  = "query"
+	SourceForm   = "form"
+	SourceHeader = "header"
+	SourcePath   = "path"
+)
+
+// ParamError is the error of a param that could not be bound.
+type ParamError struct {
+	// Source is the source of the param: "query", "form", "header" or "path".
+	Source string
+	// Name is the name of the param.
+	Name string
+	// Field is the name of the struct field.
+	Field string
+	// Err is the cause of the error.
+	Err error
+}
+
+// Error returns the message of the error.
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("httpbind: %s param %s into field %s: %v", e.Source, e.Name, e.Field, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// Lookup returns the values of the param name, and false if the param is absent.
+type Lookup func(name string) (values []string, ok bool)
+
+// Query binds the url query values to the fields of the struct pointed by dst with the `query` tag.
+func Query(values url.Values, dst any) error {
+	return Bind(SourceQuery, lookupValues(values), dst)
+}
+
+// Form binds the form values to the fields of the struct pointed by dst with the `form` tag.
+func Form(values url.Values, dst any) error {
+	return Bind(SourceForm, lookupValues(values), dst)
+}
+
+// Header binds the header values to the fields of the struct pointed by dst with the `header` tag.
+// The names of the tags are canonicalized, as http.Header.Values does.
+func Header(h http.Header, dst any) error {
+	return Bind(SourceHeader, lookupHeader(h), dst)
+}
+
+// Path binds the path params to the fields of the struct pointed by dst with the `path` tag.
+// value returns the value of a path param, an empty value is absent (e.g. http.Request.PathValue,
+// or the equivalent function of a router).
+func Path(value func(name string) string, dst any) error {
+	return Bind(SourcePath, lookupPath(value), dst)
+}
+
+// Request binds the params of r to the fields of the struct pointed by dst, for all the sources:
+// - path: r.PathValue, with the patterns of http.ServeMux (go 1.22 or later)
+// - query: r.URL.Query()
+// - form: r.PostForm, parsed with r.ParseForm (multipart forms must be parsed before with r.ParseMultipartForm)
+// - header: r.Header
+//
+// A field with the tags of several sources (e.g. `query:"page" form:"page"`) is bound from the first
+// source, in the order above, where its param is present.
+//
+// All the params are bound before returning, so the returned error joins a *ParamError for each invalid param.
+func Request(r *http.Request, dst any) (err error) {
+	if err = r.ParseForm(); err != nil {
+		return
+	}
+
+	var sources []source
+	if p, ok := any(r).(pathValuer); ok {
+		sources = append(sources, source{name: SourcePath, lookup: lookupPath(p.PathValue)})
+	}
+	sources = append(sources,
+		source{name: SourceQuery, lookup: lookupValues(r.URL.Query())},
+		source{name: SourceForm, lookup: lookupValues(r.PostForm)},
+		source{name: SourceHeader, lookup: lookupHeader(r.Header)},
+	)
+	return bind(dst, sources...)
+}
+
+// pathValuer is implemented by *http.Request since go 1.22.
+type pathValuer interface {
+	PathValue(name string) string
+}
+
+// Bind binds the params of a source to the fields of the struct pointed by dst with the tag of the source.
+// It is the extension point for other sources of params (e.g. cookies or router params).
+//
+// All the params are bound before returning, so the returned error joins a *ParamError for each invalid param.
+func Bind(name string, lookup Lookup, dst any) error {
+	return bind(dst, source{name: name, lookup: lookup})
+}
+
+// source is a source of params, its name is also its tag.
+type source struct {
+	name   string
+	lookup Lookup
+}
+
+// param is a param of a source bound to a field.
+type param struct {
+	source source
+	name   string
+}
+
+// binding is a field of a struct with its params, in the order of their sources.
+type binding struct {
+	field  structs.Field
+	params []param
+}
+
+// bind binds the params of the sources to the fields of the struct pointed by dst.
+func bind(dst any, sources ...source) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a pointer to a struct, got %T", ErrInvalidDestination, dst)
+	}
+	sv := rv.Elem()
+
+	var errs []error
+	for _, b := range bindingsOf(sv.Type(), sources) {
+		fv := sv.FieldByIndex(b.field.Index)
+
+		// the first present param is bound, the field is absent if there is none
+		p := b.params[0]
+		values, ok := []string(nil), false
+		for _, p = range b.params {
+			if values, ok = p.source.lookup(p.name); ok {
+				break
+			}
+		}
+		if ok && len(values) == 1 && isNull(b.field, fv, values[0]) {
+			ok = false
+		}
+		if !ok && !structs.IsOptional(fv.Type()) && fv.Kind() != reflect.Pointer {
+			continue
+		}
+		if err := textfield.Decode(fv, values, !ok); err != nil {
+			errs = append(errs, &ParamError{Source: p.source.name, Name: p.name, Field: b.field.StructField.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// bindingsOf returns the fields of the struct type t with the tag of any of the sources, in the order of the fields.
+func bindingsOf(t reflect.Type, sources []source) (bindings []*binding) {
+	byIndex := make(map[string]*binding)
+	for _, s := range sources {
+		for _, f := range structs.Fields(t, s.name) {
+			if f.Name == "" {
+				continue
+			}
+			key := fmt.Sprint(f.Index)
+			b, ok := byIndex[key]
+			if !ok {
+				b = &binding{field: f}
+				byIndex[key] = b
+				bindings = append(bindings, b)
+			}
+			b.params = append(b.params, param{source: s, name: f.Name})
+		}
+	}
+	slices.SortFunc(bindings, func(a, b *binding) int {
+		return slices.Compare(a.field.Index, b.field.Index)
+	})
+	return
+}
+
+// isNull returns true if the value of the field value fv is null: one of the sentinels of the field f,
+// or empty for Null fields.
+func isNull(f structs.Field, fv reflect.Value, value string) bool {
+	if slices.Contains(sentinel.Nulls(f.StructField), value) {
+		return true
+	}
+	return structs.IsNull(fv.Type()) && value == ""
+}
+
+// lookupValues returns the Lookup of url values.
+func lookupValues(values url.Values) Lookup {
+	return func(name string) (v []string, ok bool) {
+		v = values[name]
+		return v, len(v) > 0
+	}
+}
+
+// lookupHeader returns the Lookup of a header.
+func lookupHeader(h http.Header) Lookup {
+	return func(name string) (v []string, ok bool) {
+		v = h.Values(name)
+		return v, len(v) > 0
+	}
+}
+
+// lookupPath returns the Lookup of the path params returned by value.
+func lookupPath(value func(name string) string) Lookup {
+	return func(name string) (v []string, ok bool) {
+		if s := value(name); s != "" {
+			return []string{s}, true
+		}
+		return
+	}
+}
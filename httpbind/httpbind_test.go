@@ -0,0 +1,270 @@
+// the module declares go 1.21, the patterns of http.ServeMux with methods and path params are enabled for the tests
+//go:debug httpmuxgo121=0
+
+package httpbind
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+type listParams struct {
+	Page    optional.Option[int]           `query:"page"`
+	Tags    optional.Option[[]string]      `query:"tag"`
+	IDs     []int                          `query:"id"`
+	Since   optional.Option[time.Time]     `query:"since"`
+	Timeout optional.Option[time.Duration] `query:"timeout"`
+	Sort    nullable.Null[string]          `query:"sort" optional:"null="`
+	Limit   *int                           `query:"limit"`
+	Search  string                         `query:"q"`
+	Trace   optional.Option[string]        `header:"X-Trace"`
+}
+
+// id is a value with its own sql.Scanner, as uuid or decimal types.
+type id string
+
+func (i *id) Scan(src any) error {
+	*i = id(fmt.Sprint(src))
+	return nil
+}
+
+// Tests for Query
+func TestQuery(t *testing.T) {
+	t.Run("Query - values", func(t *testing.T) {
+		// arrange
+		values, err := url.ParseQuery("page=2&tag=a&tag=b&id=1&id=2&since=2024-01-02T00:00:00Z&timeout=5s&sort=name&limit=10&q=go")
+		require.NoError(t, err)
+
+		// act
+		var p listParams
+		err = Query(values, &p)
+
+		// assert
+		require.NoError(t, err)
+		limit := 10
+		require.Equal(t, listParams{
+			Page:    optional.Some(2),
+			Tags:    optional.Some([]string{"a", "b"}),
+			IDs:     []int{1, 2},
+			Since:   optional.Some(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			Timeout: optional.Some(5 * time.Second),
+			Sort:    nullable.Some("name"),
+			Limit:   &limit,
+			Search:  "go",
+		}, p)
+	})
+
+	t.Run("Query - absent params", func(t *testing.T) {
+		// arrange
+		limit := 10
+		p := listParams{Page: optional.Some(1), Limit: &limit, Search: "default", Trace: optional.Some("trace")}
+
+		// act
+		err := Query(url.Values{"sort": {""}}, &p)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, p.Page.IsSome())
+		require.False(t, p.Tags.IsSome())
+		require.True(t, p.Sort.IsNull())
+		require.Nil(t, p.Limit)
+		require.Equal(t, "default", p.Search)
+		require.Equal(t, "trace", p.Trace.Unwrap())
+	})
+
+	t.Run("Query - first value of repeated params", func(t *testing.T) {
+		// act
+		var p listParams
+		err := Query(url.Values{"page": {"1", "2"}}, &p)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1, p.Page.Unwrap())
+	})
+
+	t.Run("Query - wrappers of Option", func(t *testing.T) {
+		// arrange
+		type params struct {
+			ID     optional.StringOption[int]    `query:"id"`
+			Strict optional.StrictOption[int]    `query:"strict"`
+			Token  optional.SecretOption[string] `query:"token"`
+			Code   optional.StringOption[int]    `query:"code" optional:"null=-1"`
+		}
+		p := params{ID: optional.SomeString(1), Strict: optional.SomeStrict(2), Token: optional.SomeSecret("abc")}
+
+		// act
+		err1 := Query(url.Values{"code": {"-1"}}, &p)
+		absent := p
+		err2 := Query(url.Values{"id": {"3"}, "strict": {"4"}, "token": {"xyz"}, "code": {"5"}}, &p)
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Equal(t, params{ID: optional.NoneString[int](), Strict: optional.NoneStrict[int](), Token: optional.NoneSecret[string](), Code: optional.NoneString[int]()}, absent)
+		require.Equal(t, params{ID: optional.SomeString(3), Strict: optional.SomeStrict(4), Token: optional.SomeSecret("xyz"), Code: optional.SomeString(5)}, p)
+	})
+
+	t.Run("Query - inner values with their own Scan", func(t *testing.T) {
+		// arrange
+		type params struct {
+			ID     optional.Option[id] `query:"id"`
+			Parent nullable.Null[id]   `query:"parent"`
+		}
+
+		// act
+		var p params
+		err := Query(url.Values{"id": {"a1"}, "parent": {"b2"}}, &p)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, params{ID: optional.Some[id]("a1"), Parent: nullable.Some[id]("b2")}, p)
+	})
+
+	// errors
+	t.Run("Query - invalid values", func(t *testing.T) {
+		// act
+		var p listParams
+		err := Query(url.Values{"page": {"two"}, "id": {"1", "x"}, "q": {"go"}}, &p)
+
+		// assert
+		var paramErr *ParamError
+		require.ErrorAs(t, err, &paramErr)
+		require.Equal(t, "query", paramErr.Source)
+		require.Equal(t, "page", paramErr.Name)
+		require.Equal(t, "Page", paramErr.Field)
+		require.ErrorContains(t, err, "httpbind: query param page into field Page: ")
+		require.ErrorContains(t, err, "httpbind: query param id into field IDs: ")
+		require.Equal(t, "go", p.Search)
+	})
+
+	t.Run("Query - invalid destination", func(t *testing.T) {
+		// act
+		var p listParams
+		err := Query(url.Values{}, p)
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidDestination)
+	})
+}
+
+// Tests for Header
+func TestHeader(t *testing.T) {
+	// arrange
+	h := http.Header{}
+	h.Set("x-trace", "abc")
+
+	// act
+	var p listParams
+	err := Header(h, &p)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "abc", p.Trace.Unwrap())
+	require.False(t, p.Page.IsSome())
+}
+
+// Tests for Request
+func TestRequest(t *testing.T) {
+	type createParams struct {
+		ID      int                     `path:"id"`
+		Name    optional.Option[string] `form:"name"`
+		Age     optional.Option[int]    `form:"age"`
+		DryRun  optional.Option[bool]   `query:"dry_run"`
+		Trace   optional.Option[string] `header:"X-Trace"`
+		Comment optional.Option[string] `form:"comment"`
+	}
+
+	t.Run("Request - all the sources", func(t *testing.T) {
+		// arrange
+		var p createParams
+		var err error
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			err = Request(r, &p)
+		})
+		r := httptest.NewRequest(http.MethodPost, "/users/7?dry_run=true", strings.NewReader("name=Mary&age=20"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-Trace", "abc")
+
+		// act
+		mux.ServeHTTP(httptest.NewRecorder(), r)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, createParams{
+			ID:      7,
+			Name:    optional.Some("Mary"),
+			Age:     optional.Some(20),
+			DryRun:  optional.Some(true),
+			Trace:   optional.Some("abc"),
+			Comment: optional.None[string](),
+		}, p)
+	})
+
+	t.Run("Request - first present source", func(t *testing.T) {
+		// arrange
+		type params struct {
+			Page  optional.Option[int]  `query:"page" form:"page"`
+			Owner nullable.Null[string] `query:"owner" header:"X-Owner"`
+		}
+		r := httptest.NewRequest(http.MethodPost, "/?owner=", strings.NewReader("page=3"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-Owner", "mary")
+
+		// act
+		var p params
+		err := Request(r, &p)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 3, p.Page.Unwrap())
+		require.True(t, p.Owner.IsNull())
+	})
+
+	t.Run("Request - invalid params of several sources", func(t *testing.T) {
+		// arrange
+		r := httptest.NewRequest(http.MethodPost, "/users?dry_run=maybe", strings.NewReader("age=old"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		// act
+		var p createParams
+		err := Request(r, &p)
+
+		// assert
+		require.ErrorContains(t, err, "httpbind: query param dry_run into field DryRun: ")
+		require.ErrorContains(t, err, "httpbind: form param age into field Age: ")
+	})
+}
+
+// Tests for Bind
+func TestBind(t *testing.T) {
+	// arrange
+	type params struct {
+		Session optional.Option[string] `cookie:"session"`
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	// act
+	var p params
+	err := Bind("cookie", func(name string) ([]string, bool) {
+		c, err := r.Cookie(name)
+		if err != nil {
+			return nil, false
+		}
+		return []string{c.Value}, true
+	}, &p)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "abc", p.Session.Unwrap())
+}
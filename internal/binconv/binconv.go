@@ -0,0 +1,75 @@
+// Package binconv contains the binary encoding shared by optional.Option and nullable.Null:
+// a tag byte (None/Null or Some) followed, for Some, by the binary encoding of the inner value.
+package binconv
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+const (
+	// TagNone is the tag of a None (or Null) value, encoded as the tag alone.
+	TagNone byte = 0
+	// TagSome is the tag of a Some (or Not Null) value, followed by the encoding of the inner value.
+	TagSome byte = 1
+)
+
+var (
+	ErrInvalid = errors.New("binconv: invalid data")
+)
+
+// Marshal returns the binary encoding of the value pointed by v if some is true, or of None otherwise.
+// The inner value is encoded with its encoding.BinaryMarshaler or with gob.
+func Marshal(some bool, v any) (data []byte, err error) {
+	if !some {
+		return []byte{TagNone}, nil
+	}
+
+	if m, ok := v.(encoding.BinaryMarshaler); ok {
+		var inner []byte
+		if inner, err = m.MarshalBinary(); err != nil {
+			return
+		}
+		data = append([]byte{TagSome}, inner...)
+		return
+	}
+
+	buf := bytes.NewBuffer([]byte{TagSome})
+	if err = gob.NewEncoder(buf).Encode(v); err != nil {
+		return
+	}
+	data = buf.Bytes()
+	return
+}
+
+// Unmarshal decodes the binary data into the value pointed by v.
+// some is false if data is the encoding of None, then v is left untouched.
+func Unmarshal(data []byte, v any) (some bool, err error) {
+	if len(data) == 0 {
+		err = fmt.Errorf("%w: empty", ErrInvalid)
+		return
+	}
+
+	switch data[0] {
+	case TagNone:
+		if len(data) > 1 {
+			err = fmt.Errorf("%w: %d trailing bytes after None", ErrInvalid, len(data)-1)
+		}
+		return
+	case TagSome:
+	default:
+		err = fmt.Errorf("%w: unknown tag %#x", ErrInvalid, data[0])
+		return
+	}
+
+	if u, ok := v.(encoding.BinaryUnmarshaler); ok {
+		err = u.UnmarshalBinary(data[1:])
+	} else {
+		err = gob.NewDecoder(bytes.NewReader(data[1:])).Decode(v)
+	}
+	some = err == nil
+	return
+}
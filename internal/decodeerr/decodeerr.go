@@ -0,0 +1,108 @@
+// Package decodeerr contains the decoding error shared by optional.Option and nullable.Null,
+// so both packages return the same type without importing each other.
+package decodeerr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSnippet is the max length of the raw value in the error message.
+const maxSnippet = 64
+
+// Error is the error returned when the inner value of an Option or a Null can not be decoded.
+type Error struct {
+	// Format is the format of the document: "json", "bson", "yaml", ...
+	Format string
+	// Path is the path of the value in the document (e.g. "user.tags[1].age" for json,
+	// "user.tags.1.age" for bson). It is empty if the decoder does not know it.
+	Path string
+	// Type is the expected Go type, the type of the inner value.
+	Type reflect.Type
+	// Raw is the raw value (as extended json for bson). It is empty if the decoder does not know it.
+	Raw string
+	// Err is the error returned by the decoding of the inner value.
+	Err error
+}
+
+// Error returns the description of the error.
+func (e *Error) Error() string {
+	raw := e.Raw
+	if len(raw) > maxSnippet {
+		raw = raw[:maxSnippet] + "..."
+	}
+
+	var b strings.Builder
+	b.WriteString("optional: cannot decode " + e.Format)
+	if raw != "" {
+		b.WriteString(" " + raw)
+	}
+	b.WriteString(" into " + e.Type.String())
+	if e.Path != "" {
+		b.WriteString(" at " + e.Path)
+	}
+	b.WriteString(": " + e.Err.Error())
+	return b.String()
+}
+
+// Unwrap returns the decoding error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns the Error of decoding the raw value of the given format into a value of type t, or nil if err is nil.
+// If err already contains an Error (e.g. of an inner Option), it is returned as is.
+func New(format string, t reflect.Type, raw string, err error) error {
+	if err == nil || Contains(err) {
+		return err
+	}
+	return &Error{Format: format, Type: t, Raw: raw, Err: err}
+}
+
+// JSON returns the Error of decoding the json data into a value of type t, or nil if err is nil.
+// If err already contains an Error (e.g. of an inner Option), it is returned as is.
+func JSON(t reflect.Type, data []byte, err error) error {
+	return New("json", t, string(data), err)
+}
+
+// BSON returns the Error of decoding the bson value into a value of type t, or nil if err is nil.
+// If err already contains an Error (e.g. of an inner Option), it is returned as is.
+func BSON(t reflect.Type, bt bsontype.Type, data []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	return New("bson", t, bsoncore.Value{Type: bt, Data: data}.String(), err)
+}
+
+// YAML returns the Error of decoding the yaml node into a value of type t, or nil if err is nil.
+// If err already contains an Error (e.g. of an inner Option), it is returned as is.
+func YAML(t reflect.Type, node *yaml.Node, err error) error {
+	if err == nil || Contains(err) {
+		return err
+	}
+	raw := node.Value
+	if node.Kind != yaml.ScalarNode {
+		data, _ := yaml.Marshal(node)
+		raw = strings.TrimSpace(string(data))
+	}
+	return New("yaml", t, raw, err)
+}
+
+// Contains returns true if err contains an Error.
+func Contains(err error) bool {
+	var e *Error
+	return errors.As(err, &e)
+}
+
+// SetPath sets the path of the Error contained in err, if it has no path yet.
+func SetPath(err error, path string) {
+	var e *Error
+	if errors.As(err, &e) && e.Path == "" {
+		e.Path = path
+	}
+}
@@ -0,0 +1,41 @@
+// Package fmtconv contains the fmt.Formatter logic shared by optional.Option and nullable.Null.
+package fmtconv
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Value is an Option or a Null, as seen by Format.
+type Value interface {
+	fmt.Stringer
+	fmt.GoStringer
+}
+
+// Format formats v for the verb:
+// - %#v: v.GoString()
+// - %v and %s: v.String(), with the width, precision and flags of the verb
+// - any other verb: the inner value (if some) formatted with the same verb, width, precision and flags,
+// or v.String() (e.g. "None") padded to the width if v has no value
+//
+// inner is the inner value of v, and some reports whether v has a value.
+func Format(f fmt.State, verb rune, v Value, inner any, some bool) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, v.GoString())
+	case verb == 'v' || verb == 's':
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), v.String())
+	case some:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), inner)
+	default:
+		format := "%"
+		if f.Flag('-') {
+			format += "-"
+		}
+		if width, ok := f.Width(); ok {
+			format += strconv.Itoa(width)
+		}
+		fmt.Fprintf(f, format+"s", v.String())
+	}
+}
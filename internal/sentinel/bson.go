@@ -0,0 +1,137 @@
+package sentinel
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// BSON returns the bson document doc of a value of type t with the sentinels of its fields mapped:
+// - decoding: the values equal to a sentinel are replaced by null
+// - encoding: the null values are replaced by the first sentinel
+//
+// doc is returned as is if it has no fields with sentinels or it is not a valid document.
+func BSON(doc []byte, t reflect.Type, encode bool) []byte {
+	if !Contains(t, "bson", nil) {
+		return doc
+	}
+	out, ok := rewriteBSONDocument(doc, elemType(t), encode)
+	if !ok {
+		return doc
+	}
+	return out
+}
+
+// rewriteBSONDocument maps the sentinels of the bson document (or array) doc of type t.
+func rewriteBSONDocument(doc bsoncore.Document, t reflect.Type, encode bool) (out []byte, ok bool) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return
+	}
+	var fields []structs.Field
+	if t.Kind() == reflect.Struct {
+		fields = structs.Fields(t, "bson")
+	}
+
+	idx, out := bsoncore.AppendDocumentStart(nil)
+	for _, e := range elems {
+		key, value := e.Key(), e.Value()
+
+		var vt reflect.Type
+		switch t.Kind() {
+		case reflect.Struct:
+			f, found := bsonField(fields, key)
+			if !found {
+				break
+			}
+			if nulls := Nulls(f.StructField); len(nulls) > 0 {
+				if v := bsonNull(value, nulls, f.StructField.Type, encode); v.Type != value.Type || !bytes.Equal(v.Data, value.Data) {
+					out = bsoncore.AppendValueElement(out, key, v)
+					continue
+				}
+			}
+			vt = f.StructField.Type
+		case reflect.Map, reflect.Slice, reflect.Array:
+			vt = t.Elem()
+		}
+
+		if vt != nil {
+			if value, ok = rewriteBSONValue(value, vt, encode); !ok {
+				return
+			}
+		}
+		out = bsoncore.AppendValueElement(out, key, value)
+	}
+	out, err = bsoncore.AppendDocumentEnd(out, idx)
+	return out, err == nil
+}
+
+// rewriteBSONValue maps the sentinels of the bson value v of type t.
+func rewriteBSONValue(v bsoncore.Value, t reflect.Type, encode bool) (bsoncore.Value, bool) {
+	t = elemType(t)
+	if !Contains(t, "bson", nil) {
+		return v, true
+	}
+	switch v.Type {
+	case bsontype.EmbeddedDocument, bsontype.Array:
+		data, ok := rewriteBSONDocument(v.Data, t, encode)
+		return bsoncore.Value{Type: v.Type, Data: data}, ok
+	}
+	return v, true
+}
+
+// bsonNull maps the bson value v of a field of type t with the given sentinels.
+func bsonNull(v bsoncore.Value, nulls []string, t reflect.Type, encode bool) bsoncore.Value {
+	if encode {
+		if v.Type != bsontype.Null {
+			return v
+		}
+		if pv, ok := parse(nulls[0], t); ok {
+			if bt, data, err := bson.MarshalValue(pv); err == nil {
+				return bsoncore.Value{Type: bt, Data: data}
+			}
+		}
+		return bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, nulls[0])}
+	}
+
+	var text string
+	switch v.Type {
+	case bsontype.String:
+		text = v.StringValue()
+	case bsontype.Int32:
+		text = strconv.FormatInt(int64(v.Int32()), 10)
+	case bsontype.Int64:
+		text = strconv.FormatInt(v.Int64(), 10)
+	case bsontype.Double:
+		text = strconv.FormatFloat(v.Double(), 'g', -1, 64)
+	case bsontype.Boolean:
+		text = strconv.FormatBool(v.Boolean())
+	default:
+		return v
+	}
+	if slices.Contains(nulls, text) {
+		return bsoncore.Value{Type: bsontype.Null}
+	}
+	return v
+}
+
+// bsonField returns the field of the bson key, named by its bson tag or its lowercased name.
+func bsonField(fields []structs.Field, key string) (structs.Field, bool) {
+	for _, f := range fields {
+		name := f.Name
+		if name == "" {
+			name = strings.ToLower(f.StructField.Name)
+		}
+		if name == key {
+			return f, true
+		}
+	}
+	return structs.Field{}, false
+}
@@ -0,0 +1,171 @@
+package sentinel
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+// JSON returns the json value raw of a value of type t with the sentinels of its fields mapped:
+// - decoding: the values equal to a sentinel are replaced by null
+// - encoding: the null values are replaced by the first sentinel
+//
+// raw is returned as is if it has no fields with sentinels or it is not valid json.
+func JSON(raw []byte, t reflect.Type, encode bool) []byte {
+	if !Contains(t, "json", nil) {
+		return raw
+	}
+	out, ok := rewriteJSON(bytes.TrimSpace(raw), t, encode)
+	if !ok {
+		return raw
+	}
+	return out
+}
+
+// member is a key of a json object with its raw value.
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// rewriteJSON maps the sentinels of the raw json value of type t.
+func rewriteJSON(raw []byte, t reflect.Type, encode bool) (out []byte, ok bool) {
+	t = elemType(t)
+	if !Contains(t, "json", nil) {
+		return raw, true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		members, ok := jsonObject(raw)
+		if !ok {
+			return raw, string(raw) == "null"
+		}
+		fields := structs.JSONFields(t)
+		for i, m := range members {
+			f, found := lookupField(fields, m.key)
+			if !found {
+				continue
+			}
+			if nulls := Nulls(f.StructField); len(nulls) > 0 {
+				if value := jsonNull(m.value, nulls, f.StructField.Type, encode); !bytes.Equal(value, m.value) {
+					members[i].value = value
+					continue
+				}
+			}
+			if members[i].value, ok = rewriteJSON(m.value, f.StructField.Type, encode); !ok {
+				return nil, false
+			}
+		}
+		return appendJSONObject(nil, members), true
+
+	case reflect.Map:
+		members, ok := jsonObject(raw)
+		if !ok {
+			return raw, string(raw) == "null"
+		}
+		for i, m := range members {
+			if members[i].value, ok = rewriteJSON(m.value, t.Elem(), encode); !ok {
+				return nil, false
+			}
+		}
+		return appendJSONObject(nil, members), true
+
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return raw, string(raw) == "null"
+		}
+		for i, item := range items {
+			if items[i], ok = rewriteJSON(item, t.Elem(), encode); !ok {
+				return nil, false
+			}
+		}
+		out, err := json.Marshal(items)
+		return out, err == nil
+	}
+	return raw, true
+}
+
+// jsonNull maps the raw json value of a field of type t with the given sentinels.
+func jsonNull(raw json.RawMessage, nulls []string, t reflect.Type, encode bool) json.RawMessage {
+	if encode {
+		if string(raw) != "null" {
+			return raw
+		}
+		var data []byte
+		if v, ok := parse(nulls[0], t); ok {
+			data, _ = json.Marshal(v)
+		}
+		if data == nil {
+			data, _ = json.Marshal(nulls[0])
+		}
+		return data
+	}
+
+	text := string(raw)
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		text = s
+	}
+	for _, null := range nulls {
+		if text == null {
+			return json.RawMessage("null")
+		}
+	}
+	return raw
+}
+
+// lookupField returns the field of the json key, preferring an exact match over
+// a case-insensitive one as encoding/json does.
+func lookupField(fields []structs.Field, key string) (structs.Field, bool) {
+	for _, f := range fields {
+		if f.Name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, key) {
+			return f, true
+		}
+	}
+	return structs.Field{}, false
+}
+
+// jsonObject returns the members of the raw json object, in order.
+func jsonObject(raw []byte) (members []member, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return
+		}
+		m := member{key: tok.(string)}
+		if err = dec.Decode(&m.value); err != nil {
+			return
+		}
+		members = append(members, m)
+	}
+	return members, true
+}
+
+// appendJSONObject appends the json object of the members to dst.
+func appendJSONObject(dst []byte, members []member) []byte {
+	dst = append(dst, '{')
+	for i, m := range members {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		key, _ := json.Marshal(m.key)
+		dst = append(dst, key...)
+		dst = append(dst, ':')
+		dst = append(dst, m.value...)
+	}
+	return append(dst, '}')
+}
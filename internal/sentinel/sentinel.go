@@ -0,0 +1,87 @@
+// Package sentinel maps the legacy values that mean "no value" (e.g. "N/A", -1, "0000-00-00")
+// to None/Null for the Option and Null fields tagged with them:
+//
+//	Age optional.Option[int] `json:"age" optional:"null=-1,null=N/A"`
+//
+// Decoding, a value equal to any of the sentinels is replaced by null. Encoding, null is replaced
+// by the first sentinel, encoded as a value of T if it can be parsed as T, as a string otherwise.
+package sentinel
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textconv"
+)
+
+// Tag is the struct tag with the sentinels of a field.
+const Tag = "optional"
+
+// Nulls returns the sentinels of the struct field sf, in the order of its tag.
+// Only Option and Null fields have sentinels.
+func Nulls(sf reflect.StructField) (nulls []string) {
+	tag, ok := sf.Tag.Lookup(Tag)
+	if !ok || !structs.IsOptional(sf.Type) {
+		return
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		if null, ok := strings.CutPrefix(opt, "null="); ok {
+			nulls = append(nulls, null)
+		}
+	}
+	return
+}
+
+// Contains returns true if values of type t have fields with sentinels (in fields, elements, inner values, ...).
+// The fields are read with the given name tag (e.g. "json").
+func Contains(t reflect.Type, tag string, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	if visited == nil {
+		visited = make(map[reflect.Type]bool)
+	}
+	visited[t] = true
+
+	if structs.IsOptional(t) {
+		return Contains(structs.InnerType(t), tag, visited)
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return Contains(t.Elem(), tag, visited)
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && Contains(t.Elem(), tag, visited)
+	case reflect.Struct:
+		for _, f := range structs.Fields(t, tag) {
+			if len(Nulls(f.StructField)) > 0 || Contains(f.StructField.Type, tag, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// elemType returns the type of the values walked inside values of type t: the inner type of
+// Options and Nulls, and the element type of pointers.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch {
+		case structs.IsOptional(t):
+			t = structs.InnerType(t)
+		case t.Kind() == reflect.Pointer:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// parse returns the sentinel as a value of the inner type of the Option or Null type t.
+func parse(null string, t reflect.Type) (v any, ok bool) {
+	pv := reflect.New(structs.InnerType(t))
+	if textconv.Unmarshal(null, pv.Interface()) != nil {
+		return
+	}
+	return pv.Elem().Interface(), true
+}
@@ -0,0 +1,185 @@
+// Package sqlconv assigns the values returned by database drivers to Go values.
+// It follows the conversion rules of database/sql (Rows.Scan), which are not exported.
+package sqlconv
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Assign stores the driver value src into dst.
+// - dst must be settable
+// - src must be one of the driver.Value types (int64, float64, bool, []byte, string, time.Time)
+// - src nil (NULL) is only valid for pointers, interfaces, slices, maps and sql.Scanner implementations
+func Assign(dst reflect.Value, src any) (err error) {
+	// scanners
+	if dst.CanAddr() {
+		if scanner, ok := dst.Addr().Interface().(sql.Scanner); ok {
+			return scanner.Scan(src)
+		}
+	}
+
+	// nulls
+	if src == nil {
+		switch dst.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return fmt.Errorf("converting NULL to %s is unsupported", dst.Type())
+	}
+
+	// pointers
+	if dst.Kind() == reflect.Pointer {
+		v := reflect.New(dst.Type().Elem())
+		if err = Assign(v.Elem(), src); err != nil {
+			return
+		}
+		dst.Set(v)
+		return
+	}
+
+	// raw bytes are owned by the driver, so they are copied
+	if b, ok := src.([]byte); ok {
+		src = append([]byte(nil), b...)
+	}
+
+	// assignable values
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	// conversions
+	switch dst.Kind() {
+	case reflect.String:
+		var s string
+		if s, err = asString(src); err == nil {
+			dst.SetString(s)
+		}
+		return
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			switch s := src.(type) {
+			case string:
+				dst.SetBytes([]byte(s))
+				return nil
+			case []byte:
+				dst.SetBytes(s)
+				return nil
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch s := src.(type) {
+		case int64:
+			i = s
+		case string, []byte:
+			str, _ := asString(s)
+			if i, err = strconv.ParseInt(str, 10, dst.Type().Bits()); err != nil {
+				return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, str, dst.Kind(), err)
+			}
+		default:
+			return unsupported(src, dst)
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("converting driver.Value type %T (%d) to a %s: value out of range", src, i, dst.Kind())
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		switch s := src.(type) {
+		case int64:
+			if s < 0 {
+				return fmt.Errorf("converting driver.Value type %T (%d) to a %s: value out of range", src, s, dst.Kind())
+			}
+			u = uint64(s)
+		case string, []byte:
+			str, _ := asString(s)
+			if u, err = strconv.ParseUint(str, 10, dst.Type().Bits()); err != nil {
+				return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, str, dst.Kind(), err)
+			}
+		default:
+			return unsupported(src, dst)
+		}
+		if dst.OverflowUint(u) {
+			return fmt.Errorf("converting driver.Value type %T (%d) to a %s: value out of range", src, u, dst.Kind())
+		}
+		dst.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch s := src.(type) {
+		case float64:
+			f = s
+		case int64:
+			f = float64(s)
+		case string, []byte:
+			str, _ := asString(s)
+			if f, err = strconv.ParseFloat(str, dst.Type().Bits()); err != nil {
+				return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, str, dst.Kind(), err)
+			}
+		default:
+			return unsupported(src, dst)
+		}
+		dst.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		var b bool
+		switch s := src.(type) {
+		case bool:
+			b = s
+		case int64:
+			if s != 0 && s != 1 {
+				return fmt.Errorf("converting driver.Value type %T (%d) to a bool: invalid syntax", src, s)
+			}
+			b = s == 1
+		case string, []byte:
+			str, _ := asString(s)
+			if b, err = strconv.ParseBool(str); err != nil {
+				return fmt.Errorf("converting driver.Value type %T (%q) to a bool: %w", src, str, err)
+			}
+		default:
+			return unsupported(src, dst)
+		}
+		dst.SetBool(b)
+		return nil
+	}
+
+	// same kind values (e.g. named types)
+	if sv.Kind() == dst.Kind() && sv.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return unsupported(src, dst)
+}
+
+// asString returns the string representation of a driver value.
+func asString(src any) (s string, err error) {
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		s = strconv.FormatBool(v)
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	default:
+		err = fmt.Errorf("converting driver.Value type %T to a string is unsupported", src)
+	}
+	return
+}
+
+func unsupported(src any, dst reflect.Value) error {
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %s", src, dst.Type())
+}
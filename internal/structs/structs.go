@@ -0,0 +1,280 @@
+// Package structs contains the reflection helpers shared by the struct based
+// packages of the module (sqlbuild, sqlscan, ...).
+package structs
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Field is an exported field of a struct.
+type Field struct {
+	// Name is the name of the field from its tag.
+	// If the field has no tag, Name is empty and the caller decides the fallback.
+	Name string
+	// Options are the comma separated options of the tag after the name.
+	Options string
+	// Index is the index sequence to reach the field with reflect.Value.FieldByIndex.
+	Index []int
+	// StructField is the reflected struct field.
+	StructField reflect.StructField
+}
+
+// HasOption returns true if the tag options contain opt.
+func (f Field) HasOption(opt string) bool {
+	for _, o := range strings.Split(f.Options, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns the exported fields of the struct type t reading the given tag.
+// - fields tagged with "-" are skipped
+// - untagged embedded structs are flattened
+func Fields(t reflect.Type, tag string) (fields []Field) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return appendFields(fields, t, tag, nil)
+}
+
+func appendFields(fields []Field, t reflect.Type, tag string, index []int) []Field {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		value, hasTag := sf.Tag.Lookup(tag)
+		if value == "-" {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+
+		// embedded structs
+		if sf.Anonymous && !hasTag && sf.Type.Kind() == reflect.Struct && !IsLeaf(sf.Type) {
+			fields = appendFields(fields, sf.Type, tag, idx)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(value, ",")
+		fields = append(fields, Field{Name: name, Options: opts, Index: idx, StructField: sf})
+	}
+	return fields
+}
+
+// IsLeaf returns true if values of type t are handled as a single value instead
+// of being walked as a struct (e.g. Option, Null, time.Time, ...).
+func IsLeaf(t reflect.Type) bool {
+	if IsOptional(t) {
+		return true
+	}
+	pt := reflect.PointerTo(t)
+	for _, m := range []string{"Scan", "Value", "UnmarshalText", "MarshalText"} {
+		if _, ok := pt.MethodByName(m); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Addressable returns an addressable version of v, copying it if needed.
+func Addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	return cp
+}
+
+// Optional is implemented by *optional.Option[T].
+type Optional interface {
+	IsSome() bool
+}
+
+// Nullable is implemented by nullable.Null[T].
+type Nullable interface {
+	IsNull() bool
+}
+
+// Unwrap returns the inner value of a Some (or a Not Null) calling its Unwrap method.
+func Unwrap(v reflect.Value) reflect.Value {
+	return Addressable(v).Addr().MethodByName("Unwrap").Call(nil)[0]
+}
+
+// IsOptional returns true if t is an Option (or a wrapper of Option, as StringOption) or a Null type.
+// The types are matched by their generic type, not by their methods, so user types with
+// methods of the same names (IsSome, IsNull, ...) are not mistaken for them.
+func IsOptional(t reflect.Type) bool {
+	_, ok := setters[genericName(t)]
+	return ok
+}
+
+// IsNull returns true if t is a Null type.
+func IsNull(t reflect.Type) bool {
+	return IsOptional(t) && reflect.PointerTo(t).Implements(nullableType)
+}
+
+// Setter sets an addressable Option or Null value without knowing its type T.
+type Setter interface {
+	// SetInner sets the value to Some (or Not Null) with v, a reflect.Value of type T.
+	SetInner(v reflect.Value)
+	// Reset sets the value to None (or Null).
+	Reset()
+}
+
+// setters are the Setter constructors of the Option and Null generic types, by generic name
+// (see Register).
+var setters = make(map[string]func(v reflect.Value) Setter)
+
+// Register registers the generic type of t (an instance of Option, of its wrappers or of Null) with the constructor
+// of the Setter of its values. It is called by the optional and nullable packages on init.
+func Register(t reflect.Type, setter func(v reflect.Value) Setter) {
+	setters[genericName(t)] = setter
+}
+
+// SetterOf returns the Setter of the addressable Option or Null value v.
+// It panics if v is not an Option or a Null (see IsOptional).
+func SetterOf(v reflect.Value) Setter {
+	return setters[genericName(v.Type())](v)
+}
+
+// genericName returns the package path and the name without type arguments of the type t
+// (e.g. "github.com/LNMMusic/optional.Option" for optional.Option[int]).
+func genericName(t reflect.Type) string {
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return t.PkgPath() + "." + name
+}
+
+// InnerType returns the type T of an Option or a Null type t, from its Unwrap method.
+func InnerType(t reflect.Type) reflect.Type {
+	m, _ := reflect.PointerTo(t).MethodByName("Unwrap")
+	return m.Type.Out(0)
+}
+
+var (
+	optionalType = reflect.TypeOf((*Optional)(nil)).Elem()
+	nullableType = reflect.TypeOf((*Nullable)(nil)).Elem()
+)
+
+// filterOperators are the operators of the `optfilter` tag of all the filter builders
+// (optional.BSONFilter, sqlbuild.Where). Each builder supports a subset of them and
+// skips the fields with the others, so a single criteria struct can be used for all of them.
+var filterOperators = []string{"eq", "ne", "gt", "gte", "lt", "lte", "in", "nin", "like", "regex", "exists"}
+
+// FilterOperator returns the operator of the `optfilter` tag of the struct field sf and the options
+// after the first comma (e.g. "regex,i"). The operator is "eq" if the tag is empty.
+// skip is true if the field is tagged with "-", and known is false if the operator is not
+// supported by any filter builder.
+func FilterOperator(sf reflect.StructField) (op, opts string, skip, known bool) {
+	tag := sf.Tag.Get("optfilter")
+	if tag == "-" {
+		return "", "", true, true
+	}
+	op, opts, _ = strings.Cut(tag, ",")
+	if op == "" {
+		op = "eq"
+	}
+	return op, opts, false, slices.Contains(filterOperators, op)
+}
+
+// JSONFields returns the fields of the struct type t decoded by encoding/json, in the order of the fields:
+//   - untagged embedded structs and pointers to structs are flattened (also of unexported types)
+//   - fields tagged with "-" and unexported fields are skipped
+//   - of the fields with the same name, the shallowest one wins, the tagged one if there are several;
+//     the name is dropped if it is still ambiguous
+//
+// Name is always set, with the name of the tag or the name of the Go field.
+func JSONFields(t reflect.Type) (fields []Field) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	candidates := appendJSONFields(nil, t, nil, map[reflect.Type]bool{})
+
+	// dominant fields
+	slices.SortStableFunc(candidates, func(a, b jsonField) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if c := len(a.Index) - len(b.Index); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	for i := 0; i < len(candidates); {
+		j := i + 1
+		for j < len(candidates) && candidates[j].Name == candidates[i].Name {
+			j++
+		}
+		group := candidates[i:j]
+		if len(group) == 1 || len(group[0].Index) < len(group[1].Index) || group[0].tagged != group[1].tagged {
+			fields = append(fields, group[0].Field)
+		}
+		i = j
+	}
+	slices.SortFunc(fields, func(a, b Field) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+	return
+}
+
+// jsonField is a candidate field of JSONFields.
+type jsonField struct {
+	Field
+	// tagged is true if the name is set by the tag.
+	tagged bool
+}
+
+func appendJSONFields(fields []jsonField, t reflect.Type, index []int, visited map[reflect.Type]bool) []jsonField {
+	if visited[t] {
+		return fields
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		ft := sf.Type
+		if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous {
+			if !sf.IsExported() && ft.Kind() != reflect.Struct {
+				continue
+			}
+		} else if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// embedded structs
+		if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+			fields = appendJSONFields(fields, ft, idx, visited)
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+		f := jsonField{Field: Field{Name: name, Options: opts, Index: idx, StructField: sf}, tagged: name != ""}
+		if name == "" {
+			f.Name = sf.Name
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
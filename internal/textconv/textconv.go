@@ -0,0 +1,98 @@
+// Package textconv converts values from and to text, delegating to their encoding.TextMarshaler
+// and encoding.TextUnmarshaler or using strconv for the builtin kinds.
+// time.Duration uses its own format ("1h30m") instead of the nanoseconds.
+package textconv
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Marshal returns the text of the value pointed by v.
+func Marshal(v any) (text string, err error) {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		var data []byte
+		data, err = m.MarshalText()
+		text = string(data)
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return "", fmt.Errorf("textconv: expected a non nil pointer, got %T", v)
+	}
+	rv = rv.Elem()
+	if rv.Type() == durationType {
+		text = time.Duration(rv.Int()).String()
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		text = rv.String()
+	case reflect.Bool:
+		text = strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		text = strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		text = strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		text = strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+	default:
+		err = fmt.Errorf("textconv: unsupported type %s", rv.Type())
+	}
+	return
+}
+
+// Unmarshal sets the value pointed by v from text.
+func Unmarshal(text string, v any) (err error) {
+	if u, ok := v.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("textconv: expected a non nil pointer, got %T", v)
+	}
+	rv = rv.Elem()
+	if rv.Type() == durationType {
+		var d time.Duration
+		if d, err = time.ParseDuration(text); err == nil {
+			rv.SetInt(int64(d))
+		}
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(text); err == nil {
+			rv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(text, 10, rv.Type().Bits()); err == nil {
+			rv.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if u, err = strconv.ParseUint(text, 10, rv.Type().Bits()); err == nil {
+			rv.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(text, rv.Type().Bits()); err == nil {
+			rv.SetFloat(f)
+		}
+	default:
+		err = fmt.Errorf("textconv: unsupported type %s", rv.Type())
+	}
+	return
+}
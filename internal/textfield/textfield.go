@@ -0,0 +1,138 @@
+// Package textfield decodes and encodes struct fields from and to text values, for the text based
+// packages of the module (csvcodec, httpbind, ...).
+// The text of a single value is decoded and encoded with internal/textconv.
+package textfield
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/structs"
+	"github.com/LNMMusic/optional/internal/textconv"
+)
+
+var ErrNullElement = errors.New("textfield: null elements can not be encoded")
+
+// Decode sets the addressable field value fv from its texts:
+// - Option and Null fields: None/Null if null, Some with the texts decoded as T otherwise
+// - pointer fields: nil if null, a new value with the texts decoded otherwise
+// - slice fields: one element per text
+// - any other field: the first text, or the zero value if there are no texts
+//
+// null is ignored by any other field.
+func Decode(fv reflect.Value, texts []string, null bool) (err error) {
+	t := fv.Type()
+	switch {
+	case structs.IsOptional(t):
+		setter := structs.SetterOf(fv)
+		if null {
+			setter.Reset()
+			return
+		}
+		v := reflect.New(structs.InnerType(t)).Elem()
+		if err = Decode(v, texts, false); err != nil {
+			return
+		}
+		setter.SetInner(v)
+		return
+
+	case t.Kind() == reflect.Pointer:
+		if null {
+			fv.SetZero()
+			return
+		}
+		v := reflect.New(t.Elem())
+		if err = Decode(v.Elem(), texts, false); err != nil {
+			return
+		}
+		fv.Set(v)
+		return
+
+	case isList(t):
+		slice := reflect.MakeSlice(t, len(texts), len(texts))
+		for i, text := range texts {
+			if err = Decode(slice.Index(i), []string{text}, false); err != nil {
+				return
+			}
+		}
+		fv.Set(slice)
+		return
+	}
+
+	if len(texts) == 0 {
+		fv.SetZero()
+		return
+	}
+	return textconv.Unmarshal(texts[0], fv.Addr().Interface())
+}
+
+// Encode returns the texts of the addressable field value fv, or null if it has no value
+// (None, Null or nil pointer). Slices have a text per element, any other value a single text.
+func Encode(fv reflect.Value) (texts []string, null bool, err error) {
+	t := fv.Type()
+	switch {
+	case structs.IsOptional(t):
+		switch v := fv.Addr().Interface().(type) {
+		case structs.Optional:
+			null = !v.IsSome()
+		case structs.Nullable:
+			null = v.IsNull()
+		}
+		if null {
+			return
+		}
+		return Encode(structs.Addressable(structs.Unwrap(fv)))
+
+	case t.Kind() == reflect.Pointer:
+		if fv.IsNil() {
+			return nil, true, nil
+		}
+		return Encode(fv.Elem())
+
+	case isList(t):
+		texts = make([]string, fv.Len())
+		for i := range texts {
+			var elem []string
+			if elem, null, err = Encode(fv.Index(i)); err != nil {
+				return
+			}
+			if null {
+				return nil, false, ErrNullElement
+			}
+			texts[i] = elem[0]
+		}
+		return
+	}
+
+	text, err := textconv.Marshal(fv.Addr().Interface())
+	if err != nil {
+		return
+	}
+	texts = []string{text}
+	return
+}
+
+// IsList returns true if values of type t (or the inner values of Options, Nulls and pointers)
+// are decoded from a text per element (see Decode).
+func IsList(t reflect.Type) bool {
+	for {
+		switch {
+		case structs.IsOptional(t):
+			t = structs.InnerType(t)
+		case t.Kind() == reflect.Pointer:
+			t = t.Elem()
+		default:
+			return isList(t)
+		}
+	}
+}
+
+// isList returns true if values of type t are decoded from a text per element:
+// slices without their own encoding.TextUnmarshaler (e.g. net.IP), other than []byte.
+func isList(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
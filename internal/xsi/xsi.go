@@ -0,0 +1,31 @@
+// Package xsi contains the helpers of the xsi:nil attribute of XML Schema instances,
+// shared by the xml encoding of optional.Option and nullable.Null.
+package xsi
+
+import "encoding/xml"
+
+// Namespace is the namespace of the XML Schema instance attributes.
+const Namespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// IsNil returns true if the element has the xsi:nil="true" attribute.
+// The prefix may be declared (the namespace is resolved by the decoder) or not.
+func IsNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == Namespace || attr.Name.Space == "xsi") {
+			return attr.Value == "true" || attr.Value == "1"
+		}
+	}
+	return false
+}
+
+// Nil returns start with the xsi:nil="true" attribute and the declaration of its prefix.
+func Nil(start xml.StartElement) xml.StartElement {
+	attrs := make([]xml.Attr, 0, len(start.Attr)+2)
+	attrs = append(attrs, start.Attr...)
+	attrs = append(attrs,
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: Namespace},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+	)
+	start.Attr = attrs
+	return start
+}
@@ -1,6 +1,10 @@
 package optional
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+)
 
 // UnmarshalJSON indicates how to unmarshal a json value into an Option.
 // unmarshalling (always work with reference)
@@ -10,8 +14,23 @@ import "encoding/json"
 // cases
 // - method [ptr-receiver] -> works
 // - method [non-ptr]      -> does not work (can't access to the field of &o.Value)
+//
+// builtin scalars and time.Time are decoded without reflection (see json_fastpath.go)
+// errors are returned as *DecodeError
 func (o *Option[T]) UnmarshalJSON(data []byte) (err error) {
-	err = json.Unmarshal(data, &o.value)
+	if string(data) == "null" {
+		o.value = nil
+		return
+	}
+
+	// fast path
+	var v T
+	if unmarshalJSONFast(data, &v) {
+		o.value = &v
+		return
+	}
+
+	err = decodeerr.JSON(o.innerType(), data, json.Unmarshal(data, &o.value))
 	return
 }
 
@@ -24,7 +43,19 @@ func (o *Option[T]) UnmarshalJSON(data []byte) (err error) {
 //
 // cases
 // - method [non-ptr] -> works ()
+//
+// builtin scalars and time.Time are encoded without reflection (see json_fastpath.go)
 func (o Option[T]) MarshalJSON() (data []byte, err error) {
+	if o.value == nil {
+		data = []byte("null")
+		return
+	}
+
+	// fast path
+	if data, ok := appendJSONFast(nil, o.value); ok {
+		return data, nil
+	}
+
 	data, err = json.Marshal(o.value)
 	return
 }
\ No newline at end of file
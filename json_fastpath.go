@@ -0,0 +1,297 @@
+package optional
+
+import (
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// JSON fast paths
+// The builtin scalars and time.Time are encoded and decoded without reflection.
+// The output is byte-for-byte the same as encoding/json (HTML escaping included).
+// Any value the fast paths can not handle in the exact same way (e.g. NaN floats, strings
+// with escape sequences, invalid input, ...) falls back to encoding/json, so the errors are also the same.
+
+// appendJSONFast appends the json encoding of the value pointed by v to b.
+// ok is false if the value must be encoded by encoding/json.
+func appendJSONFast(b []byte, v any) (_ []byte, ok bool) {
+	switch v := v.(type) {
+	case *string:
+		return appendJSONString(b, *v)
+	case *bool:
+		return strconv.AppendBool(b, *v), true
+	case *int:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int8:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int16:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int32:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int64:
+		return strconv.AppendInt(b, *v, 10), true
+	case *uint:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint8:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint16:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint32:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint64:
+		return strconv.AppendUint(b, *v, 10), true
+	case *float32:
+		return appendJSONFloat(b, float64(*v), 32)
+	case *float64:
+		return appendJSONFloat(b, *v, 64)
+	case *time.Time:
+		data, err := v.MarshalJSON()
+		if err != nil {
+			return b, false
+		}
+		if len(b) == 0 {
+			return data, true
+		}
+		return append(b, data...), true
+	}
+	return b, false
+}
+
+// appendJSONFloat appends a float following the encoding/json format.
+// NaN and infinities are not supported by json, so they fall back to encoding/json for its error.
+func appendJSONFloat(b []byte, f float64, bits int) (_ []byte, ok bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return b, false
+	}
+
+	// exponent format for large and small values (as ES6)
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b, true
+}
+
+// appendJSONString appends a quoted json string escaping the html characters.
+// Control characters other than \n, \r and \t and invalid utf8 fall back to encoding/json, as their
+// escaping depends on the go version.
+func appendJSONString(b []byte, s string) (_ []byte, ok bool) {
+	const hex = "0123456789abcdef"
+
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			switch {
+			case c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&':
+				i++
+				continue
+			case c < 0x20 && c != '\n' && c != '\r' && c != '\t':
+				return b, false
+			}
+
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				// <, > and &
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			return b, false
+		case r == '\u2028' || r == '\u2029':
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+	b = append(b, '"')
+	return b, true
+}
+
+// unmarshalJSONFast decodes data into the value pointed by v.
+// ok is false if data must be decoded by encoding/json.
+func unmarshalJSONFast(data []byte, v any) (ok bool) {
+	switch v := v.(type) {
+	case *string:
+		var s string
+		if s, ok = unquoteJSONFast(data); ok {
+			*v = s
+		}
+		return
+	case *bool:
+		switch string(data) {
+		case "true":
+			*v, ok = true, true
+		case "false":
+			*v, ok = false, true
+		}
+		return
+	case *int:
+		return setJSONInt(data, v, strconv.IntSize)
+	case *int8:
+		return setJSONInt(data, v, 8)
+	case *int16:
+		return setJSONInt(data, v, 16)
+	case *int32:
+		return setJSONInt(data, v, 32)
+	case *int64:
+		return setJSONInt(data, v, 64)
+	case *uint:
+		return setJSONUint(data, v, strconv.IntSize)
+	case *uint8:
+		return setJSONUint(data, v, 8)
+	case *uint16:
+		return setJSONUint(data, v, 16)
+	case *uint32:
+		return setJSONUint(data, v, 32)
+	case *uint64:
+		return setJSONUint(data, v, 64)
+	case *float32:
+		if !isJSONNumber(data) {
+			return
+		}
+		f, err := strconv.ParseFloat(string(data), 32)
+		if err != nil {
+			return
+		}
+		*v, ok = float32(f), true
+		return
+	case *float64:
+		if !isJSONNumber(data) {
+			return
+		}
+		f, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return
+		}
+		*v, ok = f, true
+		return
+	case *time.Time:
+		return v.UnmarshalJSON(data) == nil
+	}
+	return
+}
+
+func setJSONInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](data []byte, v *T, bits int) (ok bool) {
+	if !isJSONNumber(data) {
+		return
+	}
+	i, err := strconv.ParseInt(string(data), 10, bits)
+	if err != nil {
+		return
+	}
+	*v, ok = T(i), true
+	return
+}
+
+func setJSONUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](data []byte, v *T, bits int) (ok bool) {
+	if !isJSONNumber(data) {
+		return
+	}
+	u, err := strconv.ParseUint(string(data), 10, bits)
+	if err != nil {
+		return
+	}
+	*v, ok = T(u), true
+	return
+}
+
+// unquoteJSONFast unquotes a json string without escape sequences.
+func unquoteJSONFast(data []byte) (s string, ok bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return
+	}
+	data = data[1 : len(data)-1]
+	for _, c := range data {
+		if c < 0x20 || c == '"' || c == '\\' {
+			return
+		}
+	}
+	if !utf8.Valid(data) {
+		return
+	}
+	return string(data), true
+}
+
+// isJSONNumber reports whether data is a valid json number.
+func isJSONNumber(data []byte) bool {
+	// -?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?
+	if len(data) == 0 {
+		return false
+	}
+	if data[0] == '-' {
+		data = data[1:]
+		if len(data) == 0 {
+			return false
+		}
+	}
+
+	// integer part
+	switch {
+	case data[0] == '0':
+		data = data[1:]
+	case '1' <= data[0] && data[0] <= '9':
+		data = data[1:]
+		for len(data) > 0 && '0' <= data[0] && data[0] <= '9' {
+			data = data[1:]
+		}
+	default:
+		return false
+	}
+
+	// fraction
+	if len(data) >= 2 && data[0] == '.' && '0' <= data[1] && data[1] <= '9' {
+		data = data[2:]
+		for len(data) > 0 && '0' <= data[0] && data[0] <= '9' {
+			data = data[1:]
+		}
+	}
+
+	// exponent
+	if len(data) >= 2 && (data[0] == 'e' || data[0] == 'E') {
+		data = data[1:]
+		if data[0] == '+' || data[0] == '-' {
+			data = data[1:]
+			if len(data) == 0 {
+				return false
+			}
+		}
+		for len(data) > 0 && '0' <= data[0] && data[0] <= '9' {
+			data = data[1:]
+		}
+	}
+
+	return len(data) == 0
+}
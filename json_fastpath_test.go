@@ -0,0 +1,274 @@
+package optional
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireMarshalCompatible checks that the Option encodes value as encoding/json does.
+func requireMarshalCompatible[T any](t *testing.T, value T) {
+	t.Helper()
+
+	expectedData, expectedErr := json.Marshal(value)
+	data, err := json.Marshal(Some(value))
+	if expectedErr != nil {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	require.Equal(t, string(expectedData), string(data))
+}
+
+// requireUnmarshalCompatible checks that the Option decodes data as encoding/json does.
+func requireUnmarshalCompatible[T any](t *testing.T, data string) {
+	t.Helper()
+
+	var expected T
+	expectedErr := json.Unmarshal([]byte(data), &expected)
+	var option Option[T]
+	err := json.Unmarshal([]byte(data), &option)
+	if expectedErr != nil {
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.EqualError(t, decodeErr.Err, expectedErr.Error())
+		return
+	}
+	require.NoError(t, err)
+	require.True(t, option.IsSome())
+	require.Equal(t, expected, option.Unwrap())
+}
+
+// Tests for the JSON fast path encoding
+func TestOption_MarshalFastPath(t *testing.T) {
+	t.Run("JSON - string", func(t *testing.T) {
+		values := []string{
+			"", "hello", "quote \" and backslash \\", "new\nline\ttab\rreturn",
+			"<html> & </html>", "control \x00 \x01 \x1f \b \f", "del \x7f",
+			"unicode ñ 世界 😀", "separators    ", "invalid \xff utf8 \xc3",
+		}
+		for _, v := range values {
+			requireMarshalCompatible(t, v)
+		}
+	})
+
+	t.Run("JSON - ints", func(t *testing.T) {
+		requireMarshalCompatible(t, 0)
+		requireMarshalCompatible(t, math.MinInt)
+		requireMarshalCompatible(t, int8(math.MinInt8))
+		requireMarshalCompatible(t, int16(math.MaxInt16))
+		requireMarshalCompatible(t, int32(math.MinInt32))
+		requireMarshalCompatible(t, int64(math.MaxInt64))
+	})
+
+	t.Run("JSON - uints", func(t *testing.T) {
+		requireMarshalCompatible(t, uint(math.MaxUint))
+		requireMarshalCompatible(t, uint8(math.MaxUint8))
+		requireMarshalCompatible(t, uint16(math.MaxUint16))
+		requireMarshalCompatible(t, uint32(math.MaxUint32))
+		requireMarshalCompatible(t, uint64(math.MaxUint64))
+	})
+
+	t.Run("JSON - floats", func(t *testing.T) {
+		values := []float64{
+			0, math.Copysign(0, -1), 1, -1.5, 0.1, 1e-6, 9.99e-7, 1e-7, 123456789.123,
+			1e20, 1e21, 1.5e300, math.SmallestNonzeroFloat64, math.MaxFloat64,
+			math.NaN(), math.Inf(1), math.Inf(-1),
+		}
+		for _, v := range values {
+			requireMarshalCompatible(t, v)
+			requireMarshalCompatible(t, float32(v))
+		}
+	})
+
+	t.Run("JSON - bool", func(t *testing.T) {
+		requireMarshalCompatible(t, true)
+		requireMarshalCompatible(t, false)
+	})
+
+	t.Run("JSON - time", func(t *testing.T) {
+		requireMarshalCompatible(t, time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC))
+		requireMarshalCompatible(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", -3*60*60)))
+		requireMarshalCompatible(t, time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("JSON - named types use encoding/json", func(t *testing.T) {
+		type name string
+		requireMarshalCompatible(t, name("<name>"))
+	})
+}
+
+// Tests for the JSON fast path decoding
+func TestOption_UnmarshalFastPath(t *testing.T) {
+	t.Run("JSON - string", func(t *testing.T) {
+		inputs := []string{
+			`""`, `"hello"`, `"escaped \" \\ \n ñ 😀"`, `"unicode ñ 世界"`,
+			"\"invalid \xff utf8\"", `1`, `true`, `{}`,
+		}
+		for _, in := range inputs {
+			requireUnmarshalCompatible[string](t, in)
+		}
+	})
+
+	t.Run("JSON - ints", func(t *testing.T) {
+		inputs := []string{`0`, `-0`, `42`, `-42`, `1.5`, `1e2`, `"1"`, `true`, `128`, `-129`, `9223372036854775808`}
+		for _, in := range inputs {
+			requireUnmarshalCompatible[int](t, in)
+			requireUnmarshalCompatible[int8](t, in)
+			requireUnmarshalCompatible[int16](t, in)
+			requireUnmarshalCompatible[int32](t, in)
+			requireUnmarshalCompatible[int64](t, in)
+		}
+	})
+
+	t.Run("JSON - uints", func(t *testing.T) {
+		inputs := []string{`0`, `42`, `-1`, `256`, `18446744073709551615`, `18446744073709551616`, `1.0`}
+		for _, in := range inputs {
+			requireUnmarshalCompatible[uint](t, in)
+			requireUnmarshalCompatible[uint8](t, in)
+			requireUnmarshalCompatible[uint16](t, in)
+			requireUnmarshalCompatible[uint32](t, in)
+			requireUnmarshalCompatible[uint64](t, in)
+		}
+	})
+
+	t.Run("JSON - floats", func(t *testing.T) {
+		inputs := []string{`0`, `-0.0`, `1.5`, `-1.5e-10`, `1E+2`, `3.4e39`, `1e400`, `"1.5"`, `false`}
+		for _, in := range inputs {
+			requireUnmarshalCompatible[float32](t, in)
+			requireUnmarshalCompatible[float64](t, in)
+		}
+	})
+
+	t.Run("JSON - bool", func(t *testing.T) {
+		inputs := []string{`true`, `false`, `1`, `"true"`}
+		for _, in := range inputs {
+			requireUnmarshalCompatible[bool](t, in)
+		}
+	})
+
+	t.Run("JSON - time", func(t *testing.T) {
+		inputs := []string{`"2024-01-02T03:04:05.000000006Z"`, `"2024-01-02T03:04:05-03:00"`, `"2024-01-02"`, `1`}
+		for _, in := range inputs {
+			requireUnmarshalCompatible[time.Time](t, in)
+		}
+	})
+
+	t.Run("JSON - invalid numbers on direct calls", func(t *testing.T) {
+		inputs := []string{``, `-`, `01`, `1.`, `.5`, `1e`, `1e+`, `0x10`, `1_000`, `Inf`, `NaN`, `+1`}
+		for _, in := range inputs {
+			var option Option[float64]
+			err := option.UnmarshalJSON([]byte(in))
+			require.Error(t, err, in)
+			require.False(t, isJSONNumber([]byte(in)), in)
+		}
+	})
+}
+
+// reflectOption is the reflection based implementation of the Option json encoding.
+// It is the baseline of the fast path benchmarks.
+type reflectOption[T any] struct {
+	value *T
+}
+
+func (o *reflectOption[T]) UnmarshalJSON(data []byte) error { return json.Unmarshal(data, &o.value) }
+func (o reflectOption[T]) MarshalJSON() ([]byte, error)     { return json.Marshal(o.value) }
+
+type benchmarkRecord struct {
+	ID        Option[int64]     `json:"id"`
+	Name      Option[string]    `json:"name"`
+	Score     Option[float64]   `json:"score"`
+	Active    Option[bool]      `json:"active"`
+	CreatedAt Option[time.Time] `json:"created_at"`
+	Deleted   Option[time.Time] `json:"deleted"`
+}
+
+type benchmarkReflectRecord struct {
+	ID        reflectOption[int64]     `json:"id"`
+	Name      reflectOption[string]    `json:"name"`
+	Score     reflectOption[float64]   `json:"score"`
+	Active    reflectOption[bool]      `json:"active"`
+	CreatedAt reflectOption[time.Time] `json:"created_at"`
+	Deleted   reflectOption[time.Time] `json:"deleted"`
+}
+
+// benchmarkPayload returns a payload of n records.
+func benchmarkPayload(n int) []byte {
+	records := make([]benchmarkRecord, n)
+	for i := range records {
+		records[i] = benchmarkRecord{
+			ID:        Some(int64(i)),
+			Name:      Some("user-" + strconv.Itoa(i)),
+			Score:     Some(float64(i) * 1.5),
+			Active:    Some(i%2 == 0),
+			CreatedAt: Some(time.Date(2024, 1, 1, 0, 0, i%60, 0, time.UTC)),
+		}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func BenchmarkOption_MarshalJSON(b *testing.B) {
+	data := benchmarkPayload(10000)
+	var records []benchmarkRecord
+	var reflectRecords []benchmarkReflectRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		b.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &reflectRecords); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(records); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(reflectRecords); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkOption_UnmarshalJSON(b *testing.B) {
+	data := benchmarkPayload(10000)
+
+	b.Run("fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var records []benchmarkRecord
+			if err := json.Unmarshal(data, &records); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var records []benchmarkReflectRecord
+			if err := json.Unmarshal(data, &records); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,414 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+// Report is the list of the Option values dropped by a lenient decoding.
+// Each error has the path, raw value and expected type of the value.
+type Report []*DecodeError
+
+// Err returns the errors of the report joined in a single error, or nil if there are none.
+func (r Report) Err() error {
+	errs := make([]error, len(r))
+	for i, e := range r {
+		errs[i] = e
+	}
+	return errors.Join(errs...)
+}
+
+// UnmarshalJSONLenient unmarshals data into v as json.Unmarshal, but an Option whose value can not be
+// decoded (e.g. "n/a" for an Option[int]) is set to None instead of failing the whole decoding.
+// Each dropped value is collected in the report as a *DecodeError with its path, raw value and expected type.
+// - the values outside of Options are decoded strictly, so their errors are returned as json.Unmarshal does
+// - a value that can not be decoded inside an Option[T] where T is a struct, slice or map drops the whole Option
+// - null is valid for an Option (None), but it is dropped and reported for a StrictOption
+// - the null sentinels of the fields are honored, as UnmarshalJSON does
+func UnmarshalJSONLenient(data []byte, v any) (report Report, err error) {
+	d := jsonDecoder{lenient: true}
+	err = d.unmarshalDocument(data, v)
+	report = d.report
+	return
+}
+
+// jsonDecoder decodes json values walking the Go value, so the path of each value is known.
+// Values without Options are decoded by encoding/json.
+type jsonDecoder struct {
+	// lenient sets the Options that can not be decoded to None, instead of failing.
+	lenient bool
+	// report collects the Options set to None in lenient mode.
+	report Report
+	// saved is the first type error, returned at the end of the decoding as json.Unmarshal does.
+	saved error
+	// root is the name of the type of the document, set as the Struct of the type errors if jsonRootStruct.
+	root string
+	// structType is the struct type of the field being decoded, set as the Struct of the type errors otherwise.
+	structType reflect.Type
+}
+
+// unmarshalDocument decodes the json document data into the pointer v.
+// The invalid targets and syntax errors are returned as json.Unmarshal does.
+// The values equal to the null sentinels of their fields (see MarshalJSON) are decoded as null.
+func (d *jsonDecoder) unmarshalDocument(data []byte, v any) (err error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		err = &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+		return
+	}
+	if !json.Valid(data) {
+		err = json.Unmarshal(data, new(any))
+		return
+	}
+
+	data = sentinel.JSON(data, rv.Type(), false)
+	d.root = rv.Type().Elem().Name()
+	if err = d.decode("", bytes.TrimSpace(data), rv.Elem()); err == nil {
+		err = d.saved
+	}
+	return
+}
+
+// save saves the first type error, so the decoding continues as json.Unmarshal does,
+// and returns the other errors, which stop the decoding.
+func (d *jsonDecoder) save(err error) error {
+	if _, ok := err.(*json.UnmarshalTypeError); !ok {
+		return err
+	}
+	if d.saved == nil {
+		d.saved = err
+	}
+	return nil
+}
+
+// decode decodes the raw json value into the addressable value rv.
+func (d *jsonDecoder) decode(path string, raw []byte, rv reflect.Value) (err error) {
+	if !containsOption(rv.Type(), nil) {
+		return d.unmarshal(path, raw, rv)
+	}
+	null := string(raw) == "null"
+
+	// options
+	if opt, ok := rv.Addr().Interface().(optionReflector); ok {
+		// the inner values with Options are walked (their type errors fail the option, as its
+		// UnmarshalJSON does), the others use the UnmarshalJSON of the option
+		// (null included, as it may be rejected by the option)
+		if t := opt.innerType(); !null && containsOption(t, nil) {
+			saved := d.saved
+			d.saved = nil
+			inner := reflect.New(t).Elem()
+			if err = d.decode(path, raw, inner); err == nil {
+				err = d.saved
+			}
+			if err == nil {
+				opt.setInner(inner)
+			}
+			d.saved = saved
+		} else {
+			err = rv.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(raw)
+		}
+		if err == nil {
+			return
+		}
+		err = decodeerr.JSON(opt.innerType(), raw, err)
+		decodeerr.SetPath(err, path)
+		if d.lenient {
+			var decodeErr *DecodeError
+			errors.As(err, &decodeErr)
+			opt.reset()
+			d.report = append(d.report, decodeErr)
+			err = nil
+		}
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if null {
+			rv.Set(reflect.Zero(rv.Type()))
+			return
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decode(path, raw, rv.Elem())
+
+	case reflect.Struct:
+		if null {
+			return
+		}
+		members, ok := jsonObject(raw)
+		if !ok {
+			return d.unmarshal(path, raw, rv)
+		}
+		defer func(t reflect.Type) { d.structType = t }(d.structType)
+		d.structType = rv.Type()
+
+		// the members are decoded in input order, so the last of the duplicated keys wins
+		fields := structs.JSONFields(rv.Type())
+		for _, m := range members {
+			f, ok := jsonFieldByKey(fields, m.key)
+			if !ok {
+				continue
+			}
+			var fv reflect.Value
+			if fv, err = jsonField(rv, f.Index); err != nil {
+				if d.saved == nil {
+					d.saved = err
+				}
+				continue
+			}
+			if f.HasOption("string") && isJSONQuotable(fv.Type()) {
+				err = d.unmarshalString(jsonPath(path, f.Name), m.value, fv)
+			} else {
+				err = d.decode(jsonPath(path, f.Name), m.value, fv)
+			}
+			if err = d.save(err); err != nil {
+				return
+			}
+		}
+		return
+
+	case reflect.Slice:
+		if null {
+			rv.Set(reflect.Zero(rv.Type()))
+			return
+		}
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return d.unmarshal(path, raw, rv)
+		}
+		slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err = d.save(d.decode(path+"["+strconv.Itoa(i)+"]", item, slice.Index(i))); err != nil {
+				return
+			}
+		}
+		rv.Set(slice)
+		return
+
+	case reflect.Array:
+		if null {
+			return
+		}
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return d.unmarshal(path, raw, rv)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if i >= len(items) {
+				rv.Index(i).Set(reflect.Zero(rv.Type().Elem()))
+				continue
+			}
+			if err = d.save(d.decode(path+"["+strconv.Itoa(i)+"]", items[i], rv.Index(i))); err != nil {
+				return
+			}
+		}
+		return
+
+	case reflect.Map:
+		if null {
+			rv.Set(reflect.Zero(rv.Type()))
+			return
+		}
+		members, ok := jsonObject(raw)
+		if rv.Type().Key().Kind() != reflect.String || !ok {
+			return d.unmarshal(path, raw, rv)
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMapWithSize(rv.Type(), len(members)))
+		}
+		for _, m := range members {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err = d.save(d.decode(jsonPath(path, m.key), m.value, elem)); err != nil {
+				return
+			}
+			rv.SetMapIndex(reflect.ValueOf(m.key).Convert(rv.Type().Key()), elem)
+		}
+		return
+	}
+
+	return d.unmarshal(path, raw, rv)
+}
+
+// jsonField returns the field of the struct value rv at index, allocating the nil embedded
+// pointers to structs on the way as encoding/json does.
+func jsonField(rv reflect.Value, index []int) (fv reflect.Value, err error) {
+	fv = rv
+	for i, x := range index {
+		if i > 0 && fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				if !fv.CanSet() {
+					err = fmt.Errorf("json: cannot set embedded pointer to unexported struct: %v", fv.Type().Elem())
+					return
+				}
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		fv = fv.Field(x)
+	}
+	return
+}
+
+// unmarshal decodes the raw json value into rv with encoding/json.
+// The path and the struct are added to the type errors (as json.Unmarshal does for the struct fields)
+// and the path to the *DecodeError.
+func (d *jsonDecoder) unmarshal(path string, raw []byte, rv reflect.Value) (err error) {
+	err = json.Unmarshal(raw, rv.Addr().Interface())
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		switch {
+		case jsonRootStruct && path != "":
+			typeErr.Struct = d.root
+		case !jsonRootStruct && typeErr.Struct == "" && d.structType != nil:
+			typeErr.Struct = d.structType.Name()
+		}
+		switch {
+		case typeErr.Field == "":
+			typeErr.Field = path
+		case path != "":
+			typeErr.Field = path + "." + typeErr.Field
+		}
+	}
+	decodeerr.SetPath(err, path)
+	return
+}
+
+// unmarshalString decodes a value of a field with the `,string` tag option.
+// The quoted value is decoded as the inner json value, as encoding/json does.
+func (d *jsonDecoder) unmarshalString(path string, raw []byte, rv reflect.Value) (err error) {
+	var s string
+	if string(raw) == "null" || json.Unmarshal(raw, &s) != nil {
+		return d.unmarshal(path, raw, rv)
+	}
+	return d.decode(path, []byte(s), rv)
+}
+
+// isJSONQuotable returns true if the `,string` tag option applies to values of type t.
+func isJSONQuotable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// jsonMember is a member of a json object.
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonObject returns the members of the json object raw in input order, or false if raw is not an object.
+func jsonObject(raw []byte) (members []jsonMember, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		m := jsonMember{key: t.(string)}
+		if err = dec.Decode(&m.value); err != nil {
+			return nil, false
+		}
+		members = append(members, m)
+	}
+	return members, true
+}
+
+// jsonFieldByKey returns the field of the key, preferring an exact match over
+// a case-insensitive one as encoding/json does.
+func jsonFieldByKey(fields []structs.Field, key string) (f structs.Field, ok bool) {
+	for _, f = range fields {
+		if f.Name == key {
+			return f, true
+		}
+	}
+	for _, f = range fields {
+		if strings.EqualFold(f.Name, key) {
+			return f, true
+		}
+	}
+	return structs.Field{}, false
+}
+
+// jsonPath returns the path of the key name inside path.
+func jsonPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// containsOption returns true if values of type t are Options or contain Options (in fields, elements, ...).
+// Types with their own json.Unmarshaler are decoded as a whole, so they do not contain Options.
+func containsOption(t reflect.Type, visited map[reflect.Type]bool) bool {
+	pt := reflect.PointerTo(t)
+	if pt.Implements(optionReflectorType) {
+		return true
+	}
+	if pt.Implements(jsonUnmarshalerType) || visited[t] {
+		return false
+	}
+	if visited == nil {
+		visited = make(map[reflect.Type]bool)
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return containsOption(t.Elem(), visited)
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && containsOption(t.Elem(), visited)
+	case reflect.Struct:
+		for _, f := range structs.JSONFields(t) {
+			if containsOption(f.StructField.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonRootStruct is true if encoding/json sets the root type as the Struct of the type errors
+// (as it does when backed by encoding/json/v2), instead of the innermost struct type.
+var jsonRootStruct = func() bool {
+	var probe jsonProbe
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(json.Unmarshal([]byte(`{"inner":{"value":""}}`), &probe), &typeErr) && typeErr.Struct == "jsonProbe"
+}()
+
+// jsonProbe and jsonProbeInner are the types decoded by jsonRootStruct.
+type (
+	jsonProbe struct {
+		Inner jsonProbeInner `json:"inner"`
+	}
+	jsonProbeInner struct {
+		Value int `json:"value"`
+	}
+)
+
+var (
+	optionReflectorType = reflect.TypeOf((*optionReflector)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
@@ -0,0 +1,341 @@
+package optional
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type lenientAddress struct {
+	City Option[string] `json:"city"`
+	Zip  Option[int]    `json:"zip"`
+}
+
+type lenientWebhook struct {
+	ID        int                    `json:"id"`
+	Age       Option[int]            `json:"age"`
+	Score     Option[float64]        `json:"score"`
+	Code      StringOption[int64]    `json:"code"`
+	Address   lenientAddress         `json:"address"`
+	Previous  *lenientAddress        `json:"previous"`
+	Others    []lenientAddress       `json:"others"`
+	Inner     Option[lenientAddress] `json:"inner"`
+	Tags      Option[[]string]       `json:"tags"`
+	Metadata  map[string]Option[int] `json:"metadata"`
+	Counter   int64                  `json:"counter,string"`
+	Untouched Option[string]         `json:"untouched"`
+	Ignored   Option[int]            `json:"-"`
+	Lists     [2]Option[bool]        `json:"lists"`
+}
+
+// LenientEmbedded is exported, so encoding/json can allocate it when embedded as a pointer.
+type LenientEmbedded struct {
+	Age  Option[int]    `json:"age"`
+	City Option[string] `json:"city"`
+}
+
+type lenientZip struct {
+	Zip Option[int]
+}
+
+type lenientOtherZip struct {
+	Zip Option[int]
+}
+
+type lenientEmbedding struct {
+	*LenientEmbedded
+	lenientZip
+	lenientOtherZip
+	City Option[string] `json:"city"`
+}
+
+// Tests for UnmarshalJSONLenient
+func TestUnmarshalJSONLenient(t *testing.T) {
+	t.Run("succeed to decode - same output as json.Unmarshal on valid data", func(t *testing.T) {
+		// arrange
+		data := []byte(`{
+			"id": 1,
+			"age": 20,
+			"score": null,
+			"code": "12345",
+			"address": {"city": "Lima", "zip": 15001},
+			"previous": {"CITY": "Cusco"},
+			"others": [{"city": "Arequipa"}, {}],
+			"inner": {"zip": 1},
+			"tags": ["a", "b"],
+			"metadata": {"x": 1, "y": null},
+			"counter": "42",
+			"lists": [true]
+		}`)
+		var expected lenientWebhook
+		err := json.Unmarshal(data, &expected)
+		require.NoError(t, err)
+
+		// act
+		var webhook lenientWebhook
+		report, err := UnmarshalJSONLenient(data, &webhook)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, report)
+		require.NoError(t, report.Err())
+		require.Equal(t, expected, webhook)
+	})
+
+	t.Run("succeed to decode - fields of embedded pointers to structs", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"age": 3}`)
+		var expected lenientEmbedding
+		err := json.Unmarshal(data, &expected)
+		require.NoError(t, err)
+
+		// act
+		var e lenientEmbedding
+		report, err := UnmarshalJSONLenient(data, &e)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, report)
+		require.Equal(t, expected, e)
+		require.NotNil(t, e.LenientEmbedded)
+		require.Equal(t, 3, e.Age.Unwrap())
+	})
+
+	t.Run("succeed to decode - dominant fields", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"city": "Lima", "zip": 1}`)
+		var expected lenientEmbedding
+		err := json.Unmarshal(data, &expected)
+		require.NoError(t, err)
+
+		// act
+		var e lenientEmbedding
+		report, err := UnmarshalJSONLenient(data, &e)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, report)
+		require.Equal(t, expected, e)
+		require.Equal(t, "Lima", e.City.Unwrap())
+		require.Nil(t, e.LenientEmbedded)
+		require.False(t, e.lenientZip.Zip.IsSome())
+		require.False(t, e.lenientOtherZip.Zip.IsSome())
+	})
+
+	t.Run("succeed to decode - the last of the duplicated keys wins", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"city": "Lima", "CITY": "Cusco", "Zip": 1, "zip": 2}`)
+		var expected lenientAddress
+		err := json.Unmarshal(data, &expected)
+		require.NoError(t, err)
+
+		// act
+		var address lenientAddress
+		report, err := UnmarshalJSONLenient(data, &address)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, report)
+		require.Equal(t, expected, address)
+		require.Equal(t, lenientAddress{City: Some("Cusco"), Zip: Some(2)}, address)
+	})
+
+	t.Run("succeed to decode - malformed options are none", func(t *testing.T) {
+		// arrange
+		data := []byte(`{
+			"id": 1,
+			"age": "n/a",
+			"score": 1.5,
+			"code": "12a",
+			"address": {"city": 1, "zip": 15001},
+			"others": [{"city": "Arequipa"}, {"zip": "x"}],
+			"inner": {"zip": "x"},
+			"tags": "a,b",
+			"metadata": {"x": "one"},
+			"lists": [true, "yes"]
+		}`)
+		webhook := lenientWebhook{Untouched: Some("untouched")}
+
+		// act
+		report, err := UnmarshalJSONLenient(data, &webhook)
+
+		// assert
+		require.NoError(t, err)
+		expectedWebhook := lenientWebhook{
+			ID:        1,
+			Score:     Some(1.5),
+			Address:   lenientAddress{Zip: Some(15001)},
+			Others:    []lenientAddress{{City: Some("Arequipa")}, {}},
+			Inner:     Some(lenientAddress{}),
+			Metadata:  map[string]Option[int]{"x": None[int]()},
+			Untouched: Some("untouched"),
+			Lists:     [2]Option[bool]{Some(true), None[bool]()},
+		}
+		require.Equal(t, expectedWebhook, webhook)
+
+		type diagnostic struct {
+			path string
+			raw  string
+			typ  reflect.Type
+		}
+		expectedDiagnostics := []diagnostic{
+			{path: "age", raw: `"n/a"`, typ: reflect.TypeOf(0)},
+			{path: "code", raw: `"12a"`, typ: reflect.TypeOf(int64(0))},
+			{path: "address.city", raw: `1`, typ: reflect.TypeOf("")},
+			{path: "others[1].zip", raw: `"x"`, typ: reflect.TypeOf(0)},
+			{path: "inner.zip", raw: `"x"`, typ: reflect.TypeOf(0)},
+			{path: "tags", raw: `"a,b"`, typ: reflect.TypeOf([]string{})},
+			{path: "metadata.x", raw: `"one"`, typ: reflect.TypeOf(0)},
+			{path: "lists[1]", raw: `"yes"`, typ: reflect.TypeOf(true)},
+		}
+		require.Len(t, report, len(expectedDiagnostics))
+		for _, expected := range expectedDiagnostics {
+			var found bool
+			for _, d := range report {
+				if d.Path == expected.path {
+					found = true
+					require.Equal(t, expected.raw, d.Raw)
+					require.Equal(t, expected.typ, d.Type)
+					require.Error(t, d.Err)
+				}
+			}
+			require.True(t, found, expected.path)
+		}
+		require.Error(t, report.Err())
+	})
+
+	t.Run("succeed to decode - the option with a malformed inner value is none", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"inner": "not an address"}`)
+		webhook := lenientWebhook{Inner: Some(lenientAddress{})}
+
+		// act
+		report, err := UnmarshalJSONLenient(data, &webhook)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, webhook.Inner.IsSome())
+		require.Len(t, report, 1)
+		require.Equal(t, "inner", report[0].Path)
+		require.EqualError(t, report[0], `optional: cannot decode json "not an address" into optional.lenientAddress at inner: `+report[0].Err.Error())
+	})
+
+	t.Run("succeed to decode - top level option", func(t *testing.T) {
+		// arrange
+		var option Option[int]
+
+		// act
+		report, err := UnmarshalJSONLenient([]byte(`"n/a"`), &option)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, option.IsSome())
+		require.Len(t, report, 1)
+		require.Equal(t, "", report[0].Path)
+	})
+
+	t.Run("succeed to decode - value without options", func(t *testing.T) {
+		// arrange
+		var value []int
+
+		// act
+		report, err := UnmarshalJSONLenient([]byte(`[1, 2]`), &value)
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, report)
+		require.Equal(t, []int{1, 2}, value)
+	})
+
+	t.Run("fail to decode - mismatch outside of an option", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"age": "n/a", "others": [{}, {}, "x"]}`)
+
+		// act
+		var webhook lenientWebhook
+		_, err := UnmarshalJSONLenient(data, &webhook)
+
+		// assert
+		var typeErr *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeErr)
+		require.Equal(t, "others[2]", typeErr.Field)
+	})
+
+	t.Run("fail to decode - mismatches do not stop the decoding", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"id": "x", "age": 2, "others": [{}, "y", {"city": "Lima"}]}`)
+		var expected lenientWebhook
+		expectedErr := json.Unmarshal(data, &expected)
+		require.Error(t, expectedErr)
+
+		// act
+		var webhook lenientWebhook
+		report, err := UnmarshalJSONLenient(data, &webhook)
+
+		// assert
+		require.Empty(t, report)
+		require.EqualError(t, err, expectedErr.Error())
+		var typeErr *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeErr)
+		require.Equal(t, "lenientWebhook", typeErr.Struct)
+		require.Equal(t, "id", typeErr.Field)
+		require.Equal(t, expected, webhook)
+		require.Equal(t, 2, webhook.Age.Unwrap())
+	})
+
+	t.Run("fail to decode - mismatch in a nested struct", func(t *testing.T) {
+		// arrange
+		type limits struct {
+			Max int `json:"max"`
+		}
+		type plan struct {
+			Name   Option[string] `json:"name"`
+			Limits limits         `json:"limits"`
+		}
+		data := []byte(`{"name": "basic", "limits": {"max": "x"}}`)
+		var expected plan
+		expectedErr := json.Unmarshal(data, &expected)
+		require.Error(t, expectedErr)
+
+		// act
+		var p plan
+		_, err := UnmarshalJSONLenient(data, &p)
+
+		// assert
+		require.EqualError(t, err, expectedErr.Error())
+		var typeErr *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeErr)
+		require.Equal(t, "limits.max", typeErr.Field)
+		require.Equal(t, expected, p)
+	})
+
+	t.Run("fail to decode - string option outside of an option", func(t *testing.T) {
+		// act
+		var webhook lenientWebhook
+		_, err := UnmarshalJSONLenient([]byte(`{"counter": "x"}`), &webhook)
+
+		// assert
+		require.Error(t, err)
+	})
+
+	t.Run("fail to decode - syntax error", func(t *testing.T) {
+		// act
+		var webhook lenientWebhook
+		_, err := UnmarshalJSONLenient([]byte(`{"age": `), &webhook)
+
+		// assert
+		var syntaxErr *json.SyntaxError
+		require.ErrorAs(t, err, &syntaxErr)
+	})
+
+	t.Run("fail to decode - invalid target", func(t *testing.T) {
+		// act
+		_, err := UnmarshalJSONLenient([]byte(`{}`), lenientWebhook{})
+
+		// assert
+		var invalidErr *json.InvalidUnmarshalError
+		require.ErrorAs(t, err, &invalidErr)
+	})
+}
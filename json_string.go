@@ -0,0 +1,90 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+)
+
+// Quotable is the set of types that can be encoded as a JSON string by StringOption.
+type Quotable interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 |
+		~bool
+}
+
+// StringOption is an Option whose inner value is encoded as a JSON string,
+// as the `,string` tag option does for plain numbers and bools.
+// encoding/json ignores `,string` for types with a custom UnmarshalJSON (as Option),
+// so fields such as `json:"id,string"` must use StringOption instead.
+//
+// - marshal: Some(12345) -> "12345", None -> null
+// - unmarshal: "12345" or 12345 -> Some(12345), null or "null" -> None
+//
+// The methods of the embedded Option (IsSome, Unwrap, ...) are promoted.
+type StringOption[T Quotable] struct {
+	Option[T]
+}
+
+// SomeString returns a StringOption with a Some value.
+func SomeString[T Quotable](value T) StringOption[T] {
+	return StringOption[T]{Option: Some(value)}
+}
+
+// NoneString returns a StringOption with a None value.
+func NoneString[T Quotable]() StringOption[T] {
+	return StringOption[T]{Option: None[T]()}
+}
+
+// UnmarshalJSON indicates how to unmarshal a quoted json value into a StringOption.
+// Unquoted values are also accepted, so both "12345" and 12345 are valid.
+// As with the `,string` tag option, both null and "null" are decoded as None.
+// Errors are returned as *DecodeError.
+func (o *StringOption[T]) UnmarshalJSON(data []byte) (err error) {
+	if bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte(`"null"`)) {
+		o.value = nil
+		return
+	}
+
+	var v T
+	if err = unmarshalQuoted(data, &v); err != nil {
+		err = decodeerr.JSON(o.innerType(), data, err)
+		return
+	}
+	o.value = &v
+	return
+}
+
+// unmarshalQuoted decodes a quoted or unquoted json value into v.
+func unmarshalQuoted[T Quotable](data []byte, v *T) (err error) {
+	// unquote
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err = json.Unmarshal(data, &s); err != nil {
+			return
+		}
+		data = []byte(s)
+	}
+
+	err = json.Unmarshal(data, v)
+	return
+}
+
+// MarshalJSON indicates how to marshal a StringOption into a quoted json value.
+// The output is the same as the `,string` tag option for the inner type.
+func (o StringOption[T]) MarshalJSON() (data []byte, err error) {
+	if o.value == nil {
+		data = []byte("null")
+		return
+	}
+
+	data, err = json.Marshal(*o.value)
+	if err != nil {
+		return
+	}
+	data = strconv.AppendQuote(nil, string(data))
+	return
+}
@@ -0,0 +1,184 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for StringOption UnmarshalJSON
+func TestStringOption_Unmarshal(t *testing.T) {
+	type testStruct struct {
+		Int     StringOption[int]     `json:"int"`
+		Int8    StringOption[int8]    `json:"int8"`
+		Int64   StringOption[int64]   `json:"int64"`
+		Uint    StringOption[uint]    `json:"uint"`
+		Uint16  StringOption[uint16]  `json:"uint16"`
+		Uint64  StringOption[uint64]  `json:"uint64"`
+		Float32 StringOption[float32] `json:"float32"`
+		Float64 StringOption[float64] `json:"float64"`
+		Bool    StringOption[bool]    `json:"bool"`
+	}
+
+	t.Run("JSON - quoted values", func(t *testing.T) {
+		// arrange
+		data := []byte(`{
+			"int": "-1",
+			"int8": "127",
+			"int64": "9007199254740993",
+			"uint": "1",
+			"uint16": "65535",
+			"uint64": "18446744073709551615",
+			"float32": "1.5",
+			"float64": "-2.25e10",
+			"bool": "true"
+		}`)
+
+		// act
+		var ts testStruct
+		err := json.Unmarshal(data, &ts)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, -1, ts.Int.Unwrap())
+		require.Equal(t, int8(127), ts.Int8.Unwrap())
+		require.Equal(t, int64(9007199254740993), ts.Int64.Unwrap())
+		require.Equal(t, uint(1), ts.Uint.Unwrap())
+		require.Equal(t, uint16(65535), ts.Uint16.Unwrap())
+		require.Equal(t, uint64(18446744073709551615), ts.Uint64.Unwrap())
+		require.Equal(t, float32(1.5), ts.Float32.Unwrap())
+		require.Equal(t, -2.25e10, ts.Float64.Unwrap())
+		require.Equal(t, true, ts.Bool.Unwrap())
+	})
+
+	t.Run("JSON - unquoted values", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"int": 1, "uint": 2, "float64": 1.5, "bool": false}`)
+
+		// act
+		var ts testStruct
+		err := json.Unmarshal(data, &ts)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1, ts.Int.Unwrap())
+		require.Equal(t, uint(2), ts.Uint.Unwrap())
+		require.Equal(t, 1.5, ts.Float64.Unwrap())
+		require.Equal(t, false, ts.Bool.Unwrap())
+		require.False(t, ts.Int64.IsSome())
+	})
+
+	t.Run("JSON - null", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"int": null, "bool": null}`)
+		ts := testStruct{Int: SomeString(1), Bool: SomeString(true)}
+
+		// act
+		err := json.Unmarshal(data, &ts)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, ts.Int.IsSome())
+		require.False(t, ts.Bool.IsSome())
+	})
+
+	t.Run("JSON - quoted null", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"int": "null", "bool": "null"}`)
+		ts := testStruct{Int: SomeString(1), Bool: SomeString(true)}
+
+		// act
+		err := json.Unmarshal(data, &ts)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, ts.Int.IsSome())
+		require.False(t, ts.Bool.IsSome())
+	})
+
+	// errors
+	type errorCase struct {
+		title string
+		data  string
+	}
+	errorCases := []errorCase{
+		{title: "JSON - int invalid syntax", data: `{"int": "12a"}`},
+		{title: "JSON - int8 out of range", data: `{"int8": "128"}`},
+		{title: "JSON - uint negative", data: `{"uint": "-1"}`},
+		{title: "JSON - uint64 out of range", data: `{"uint64": "18446744073709551616"}`},
+		{title: "JSON - float64 invalid syntax", data: `{"float64": "1.5.5"}`},
+		{title: "JSON - bool invalid syntax", data: `{"bool": "yes"}`},
+		{title: "JSON - empty string", data: `{"int": ""}`},
+		{title: "JSON - double quoted", data: `{"int": "\"1\""}`},
+	}
+	for _, c := range errorCases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			var ts testStruct
+			err := json.Unmarshal([]byte(c.data), &ts)
+
+			// assert
+			require.Error(t, err)
+		})
+	}
+}
+
+// Tests for StringOption MarshalJSON
+func TestStringOption_Marshal(t *testing.T) {
+	type input struct{ value any }
+	type output struct {
+		data []byte
+		err  error
+	}
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		{title: "JSON - int", input: input{value: SomeString(-1)}, output: output{data: []byte(`"-1"`)}},
+		{title: "JSON - int64", input: input{value: SomeString(int64(9007199254740993))}, output: output{data: []byte(`"9007199254740993"`)}},
+		{title: "JSON - uint64", input: input{value: SomeString(uint64(18446744073709551615))}, output: output{data: []byte(`"18446744073709551615"`)}},
+		{title: "JSON - float32", input: input{value: SomeString(float32(1.1))}, output: output{data: []byte(`"1.1"`)}},
+		{title: "JSON - float64", input: input{value: SomeString(1e21)}, output: output{data: []byte(`"1e+21"`)}},
+		{title: "JSON - bool", input: input{value: SomeString(true)}, output: output{data: []byte(`"true"`)}},
+		{title: "JSON - null", input: input{value: NoneString[int]()}, output: output{data: []byte(`null`)}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			data, err := json.Marshal(c.input.value)
+
+			// assert
+			require.Equal(t, c.output.data, data)
+			require.ErrorIs(t, err, c.output.err)
+		})
+	}
+
+	t.Run("JSON - same output as the string tag option", func(t *testing.T) {
+		// arrange
+		type plain struct {
+			ID    int64   `json:"id,string"`
+			Price float64 `json:"price,string"`
+			Valid bool    `json:"valid,string"`
+		}
+		type optional struct {
+			ID    StringOption[int64]   `json:"id"`
+			Price StringOption[float64] `json:"price"`
+			Valid StringOption[bool]    `json:"valid"`
+		}
+
+		// act
+		expectedData, err := json.Marshal(plain{ID: 12345, Price: 0.000001, Valid: true})
+		require.NoError(t, err)
+		data, err := json.Marshal(optional{ID: SomeString[int64](12345), Price: SomeString(0.000001), Valid: SomeString(true)})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, expectedData, data)
+	})
+}
@@ -0,0 +1,11 @@
+package optional
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer: the inner value for Some, and nil (null in json logs) for None.
+func (o Option[T]) LogValue() slog.Value {
+	if o.value == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.AnyValue(*o.value)
+}
@@ -0,0 +1,66 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// logJSON logs the attrs with a slog.JSONHandler and returns the decoded attrs.
+func logJSON(t *testing.T, args ...any) map[string]any {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("msg", args...)
+
+	var attrs map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &attrs))
+	return attrs
+}
+
+// Tests for Option LogValue
+func TestOption_LogValue(t *testing.T) {
+	t.Run("Log - some and none", func(t *testing.T) {
+		// act
+		attrs := logJSON(t, "age", Some(42), "name", None[string]())
+
+		// assert
+		require.Equal(t, map[string]any{"age": float64(42), "name": nil}, attrs)
+	})
+
+	t.Run("Log - struct", func(t *testing.T) {
+		// act
+		attrs := logJSON(t, "address", Some(struct{ City string }{City: "Lima"}))
+
+		// assert
+		require.Equal(t, map[string]any{"address": map[string]any{"City": "Lima"}}, attrs)
+	})
+
+	t.Run("Log - text handler", func(t *testing.T) {
+		// arrange
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+
+		// act
+		logger.Info("msg", "age", Some(42))
+
+		// assert
+		require.Equal(t, "level=INFO msg=msg age=42\n", buf.String())
+	})
+}
@@ -0,0 +1,103 @@
+package optional
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+// Layer is a named layer of configuration for MergeLayers (e.g. "defaults", "file", "env", "flags").
+type Layer[T any] struct {
+	// Name is the name of the layer in the Provenance.
+	Name string
+	// Value is the configuration of the layer.
+	Value T
+}
+
+// Provenance maps the path of each merged field (e.g. "DB.Host") to the name of the layer that supplied it.
+// The fields not supplied by any layer are absent.
+type Provenance map[string]string
+
+// Merge merges the layers of configuration, the later layers overriding the earlier ones
+// (e.g. defaults, file, env, flags). See MergeLayers for the rules of each field.
+func Merge[T any](layers ...T) (merged T) {
+	named := make([]Layer[T], len(layers))
+	for i, l := range layers {
+		named[i] = Layer[T]{Name: strconv.Itoa(i), Value: l}
+	}
+	merged, _ = MergeLayers(named...)
+	return
+}
+
+// MergeLayers merges the layers of configuration, the later layers overriding the earlier ones,
+// and returns which layer supplied each field:
+// - Options: a Some overrides, a None is skipped (a whole Option[struct] overrides)
+// - Nulls: a Not Null overrides, a Null is skipped (use Option[nullable.Null[T]] to override with Null)
+// - structs: merged field by field, only the exported fields
+// - pointers to structs: a nil pointer is skipped, the others are merged as structs into a new pointer
+// - any other field: a non zero value overrides
+func MergeLayers[T any](layers ...Layer[T]) (merged T, provenance Provenance) {
+	provenance = make(Provenance)
+	dst := reflect.ValueOf(&merged).Elem()
+	for i := range layers {
+		src := reflect.ValueOf(&layers[i].Value).Elem()
+		mergeValue(dst, src, "", layers[i].Name, provenance)
+	}
+	return
+}
+
+// mergeValue merges the addressable value src of the layer into dst, whose path is path.
+func mergeValue(dst, src reflect.Value, path, layer string, provenance Provenance) {
+	// options
+	if opt, ok := asOptionReflector(src); ok {
+		if opt.IsSome() {
+			d, _ := asOptionReflector(dst)
+			d.setInner(opt.innerValue())
+			provenance[path] = layer
+		}
+		return
+	}
+
+	// nulls
+	if structs.IsNull(src.Type()) {
+		if !src.Interface().(structs.Nullable).IsNull() {
+			dst.Set(src)
+			provenance[path] = layer
+		}
+		return
+	}
+
+	// pointers to structs: merged into a pointer of the merged value, never shared with the layers
+	if src.Kind() == reflect.Pointer && src.Type().Elem().Kind() == reflect.Struct && !structs.IsLeaf(src.Type().Elem()) {
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(src.Type().Elem()))
+		}
+		mergeValue(dst.Elem(), src.Elem(), path, layer, provenance)
+		return
+	}
+
+	// structs
+	if src.Kind() == reflect.Struct && !structs.IsLeaf(src.Type()) {
+		for i := 0; i < src.NumField(); i++ {
+			sf := src.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name := sf.Name
+			if path != "" {
+				name = path + "." + name
+			}
+			mergeValue(dst.Field(i), src.Field(i), name, layer, provenance)
+		}
+		return
+	}
+
+	if !src.IsZero() {
+		dst.Set(src)
+		provenance[path] = layer
+	}
+}
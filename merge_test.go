@@ -0,0 +1,155 @@
+package optional
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+type mergeDB struct {
+	Host Option[string] `json:"host"`
+	Port Option[int]    `json:"port"`
+}
+
+type mergeConfig struct {
+	Name     Option[string]
+	Timeout  Option[time.Duration]
+	Region   nullable.Null[string]
+	Owner    Option[nullable.Null[string]]
+	Password SecretOption[string]
+	Workers  int
+	Tags     []string
+	DB       mergeDB
+	Replica  Option[mergeDB]
+}
+
+// Tests for Merge and MergeLayers
+func TestMerge(t *testing.T) {
+	defaults := mergeConfig{
+		Name:    Some("api"),
+		Timeout: Some(time.Second),
+		Workers: 4,
+		Owner:   Some(nullable.Some("ops")),
+		DB:      mergeDB{Host: Some("localhost"), Port: Some(5432)},
+		Replica: Some(mergeDB{Host: Some("replica"), Port: Some(5432)}),
+	}
+	file := mergeConfig{
+		Timeout: Some(5 * time.Second),
+		Region:  nullable.Some("eu"),
+		DB:      mergeDB{Host: Some("db.internal")},
+		Replica: Some(mergeDB{Host: Some("replica.internal")}),
+	}
+	env := mergeConfig{
+		Password: SomeSecret("hunter2"),
+		Owner:    Some(nullable.None[string]()),
+		Tags:     []string{"a"},
+	}
+	flags := mergeConfig{
+		Timeout: Some(10 * time.Second),
+		DB:      mergeDB{Port: Some(6432)},
+	}
+
+	t.Run("Merge - later layers override", func(t *testing.T) {
+		// act
+		merged := Merge(defaults, file, env, flags)
+
+		// assert
+		require.Equal(t, mergeConfig{
+			Name:     Some("api"),
+			Timeout:  Some(10 * time.Second),
+			Region:   nullable.Some("eu"),
+			Owner:    Some(nullable.None[string]()),
+			Password: SomeSecret("hunter2"),
+			Workers:  4,
+			Tags:     []string{"a"},
+			DB:       mergeDB{Host: Some("db.internal"), Port: Some(6432)},
+			Replica:  Some(mergeDB{Host: Some("replica.internal")}),
+		}, merged)
+	})
+
+	t.Run("Merge - provenance", func(t *testing.T) {
+		// act
+		_, provenance := MergeLayers(
+			Layer[mergeConfig]{Name: "defaults", Value: defaults},
+			Layer[mergeConfig]{Name: "file", Value: file},
+			Layer[mergeConfig]{Name: "env", Value: env},
+			Layer[mergeConfig]{Name: "flags", Value: flags},
+		)
+
+		// assert
+		require.Equal(t, Provenance{
+			"Name":     "defaults",
+			"Timeout":  "flags",
+			"Region":   "file",
+			"Owner":    "env",
+			"Password": "env",
+			"Workers":  "defaults",
+			"Tags":     "env",
+			"DB.Host":  "file",
+			"DB.Port":  "flags",
+			"Replica":  "file",
+		}, provenance)
+	})
+
+	t.Run("Merge - layers are not modified", func(t *testing.T) {
+		// arrange
+		base := mergeConfig{Name: Some("api")}
+
+		// act
+		merged := Merge(base, mergeConfig{Workers: 2})
+		*merged.Name.value = "changed"
+
+		// assert
+		require.Equal(t, "api", base.Name.Unwrap())
+	})
+
+	t.Run("Merge - pointers to structs", func(t *testing.T) {
+		// arrange
+		type config struct {
+			DB      *mergeDB
+			Replica *mergeDB
+		}
+		a := config{DB: &mergeDB{Host: Some("localhost"), Port: Some(1)}}
+		b := config{DB: &mergeDB{Port: Some(2)}}
+
+		// act
+		merged, provenance := MergeLayers(Layer[config]{Name: "a", Value: a}, Layer[config]{Name: "b", Value: b})
+		*merged.DB.Port.value = 3
+
+		// assert
+		require.Equal(t, "localhost", merged.DB.Host.Unwrap())
+		require.Nil(t, merged.Replica)
+		require.Equal(t, Provenance{"DB.Host": "a", "DB.Port": "b"}, provenance)
+		require.NotSame(t, a.DB, merged.DB)
+		require.NotSame(t, b.DB, merged.DB)
+		require.Equal(t, 2, b.DB.Port.Unwrap())
+	})
+
+	t.Run("Merge - values with an IsNull method are not Nulls", func(t *testing.T) {
+		// act
+		merged := Merge(resetNullLike{Street: "Main"}, resetNullLike{City: Some("Lima")})
+
+		// assert
+		require.Equal(t, resetNullLike{Street: "Main", City: Some("Lima")}, merged)
+	})
+
+	t.Run("Merge - no layers", func(t *testing.T) {
+		// act
+		merged, provenance := MergeLayers[mergeConfig]()
+
+		// assert
+		require.Equal(t, mergeConfig{}, merged)
+		require.Empty(t, provenance)
+	})
+
+	t.Run("Merge - option layers", func(t *testing.T) {
+		// act
+		merged, provenance := MergeLayers(Layer[Option[int]]{Name: "a", Value: Some(1)}, Layer[Option[int]]{Name: "b", Value: None[int]()})
+
+		// assert
+		require.Equal(t, 1, merged.Unwrap())
+		require.Equal(t, Provenance{"": "a"}, provenance)
+	})
+}
@@ -0,0 +1,42 @@
+package nullable
+
+import (
+	"github.com/LNMMusic/optional/internal/binconv"
+	"github.com/LNMMusic/optional/internal/decodeerr"
+)
+
+// Binary encoding
+// A Null is encoded as a tag byte: 0 for Null, or 1 for Not Null followed by the inner value
+// (encoded with its encoding.BinaryMarshaler or with gob).
+// The gob methods use the same encoding, as gob can not reach the unexported fields of a Null.
+
+// MarshalBinary indicates how to marshal a Null into binary data.
+func (n Null[T]) MarshalBinary() (data []byte, err error) {
+	return binconv.Marshal(n.valid, &n.value)
+}
+
+// UnmarshalBinary indicates how to unmarshal binary data into a Null (errors as *DecodeError).
+func (n *Null[T]) UnmarshalBinary(data []byte) (err error) {
+	var v T
+	some, err := binconv.Unmarshal(data, &v)
+	if err != nil {
+		err = decodeerr.New("binary", n.innerType(), "", err)
+		return
+	}
+
+	*n = None[T]()
+	if some {
+		*n = Some(v)
+	}
+	return
+}
+
+// GobEncode indicates how to encode a Null with encoding/gob.
+func (n Null[T]) GobEncode() (data []byte, err error) {
+	return n.MarshalBinary()
+}
+
+// GobDecode indicates how to decode a Null with encoding/gob.
+func (n *Null[T]) GobDecode(data []byte) (err error) {
+	return n.UnmarshalBinary(data)
+}
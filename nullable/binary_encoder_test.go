@@ -0,0 +1,72 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type gobContact struct {
+	Phone Null[string]
+}
+
+type gobPerson struct {
+	Name     Null[string]
+	Age      Null[int]
+	Tags     Null[[]string]
+	Contact  Null[gobContact]
+	Contacts []gobContact
+}
+
+// Tests for the Null gob encoding
+func TestNull_Gob(t *testing.T) {
+	t.Run("Gob - round trip", func(t *testing.T) {
+		// arrange
+		p := gobPerson{
+			Name:     Some("John"),
+			Age:      None[int](),
+			Tags:     Some([]string{"a", "b"}),
+			Contact:  Some(gobContact{Phone: Some("123")}),
+			Contacts: []gobContact{{Phone: None[string]()}, {Phone: Some("")}},
+		}
+
+		// act
+		var buf bytes.Buffer
+		err := gob.NewEncoder(&buf).Encode(p)
+		require.NoError(t, err)
+		var decoded gobPerson
+		err = gob.NewDecoder(&buf).Decode(&decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, p, decoded)
+	})
+}
+
+// Tests for Null MarshalBinary and UnmarshalBinary
+func TestNull_Binary(t *testing.T) {
+	t.Run("Binary - null", func(t *testing.T) {
+		// act
+		data, err := None[int]().MarshalBinary()
+		require.NoError(t, err)
+		n := Some(1)
+		err = n.UnmarshalBinary(data)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []byte{0}, data)
+		require.True(t, n.IsNull())
+	})
+
+	t.Run("Binary - invalid data", func(t *testing.T) {
+		// act
+		var n Null[int]
+		err := n.UnmarshalBinary([]byte{7})
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+	})
+}
@@ -0,0 +1,123 @@
+package nullable
+
+import (
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DecodeError is the error returned when the inner value of a Null can not be decoded.
+// It is the same type as optional.DecodeError.
+type DecodeError = decodeerr.Error
+
+// nullEncoder gives non generic access to a Null[T] to encode it.
+type nullEncoder interface {
+	IsNull() bool
+	// innerValue returns the inner value as a reflect.Value of type T.
+	innerValue() reflect.Value
+}
+
+// nullDecoder gives non generic access to a *Null[T] to decode it.
+type nullDecoder interface {
+	// innerType returns the type T of the Null.
+	innerType() reflect.Type
+	// setInner sets the Null to Not Null with v, a reflect.Value of type T.
+	setInner(v reflect.Value)
+	// reset sets the Null to Null.
+	reset()
+}
+
+var (
+	tNullEncoder = reflect.TypeOf((*nullEncoder)(nil)).Elem()
+	tNullDecoder = reflect.TypeOf((*nullDecoder)(nil)).Elem()
+)
+
+func (n Null[T]) innerValue() reflect.Value {
+	return reflect.ValueOf(&n.value).Elem()
+}
+
+func (n *Null[T]) innerType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (n *Null[T]) setInner(v reflect.Value) {
+	var value T
+	reflect.ValueOf(&value).Elem().Set(v)
+	*n = Some(value)
+}
+
+func (n *Null[T]) reset() {
+	*n = None[T]()
+}
+
+// RegisterBSONCodecs registers the Null codecs on rb.
+// The inner values are encoded and decoded with the registry being built,
+// so the custom codecs of the caller are also applied inside Nulls.
+// - Null is encoded as null, null and undefined are decoded as Null
+// - Not Null is encoded and decoded with the codecs of T (decoding errors as *DecodeError)
+func RegisterBSONCodecs(rb *bsoncodec.RegistryBuilder) *bsoncodec.RegistryBuilder {
+	rb.RegisterHookEncoder(tNullEncoder, bsoncodec.ValueEncoderFunc(nullEncodeValue))
+	rb.RegisterHookDecoder(tNullDecoder, bsoncodec.ValueDecoderFunc(nullDecodeValue))
+	return rb
+}
+
+// nullEncodeValue is the bsoncodec.ValueEncoderFunc of Null.
+func nullEncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) (err error) {
+	if val.Kind() == reflect.Pointer && val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	n := val.Interface().(nullEncoder)
+	if n.IsNull() {
+		return vw.WriteNull()
+	}
+
+	inner := n.innerValue()
+	enc, err := ec.LookupEncoder(inner.Type())
+	if err != nil {
+		return
+	}
+	err = enc.EncodeValue(ec, vw, inner)
+	return
+}
+
+// nullDecodeValue is the bsoncodec.ValueDecoderFunc of Null.
+func nullDecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) (err error) {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+	} else {
+		val = val.Addr()
+	}
+
+	n := val.Interface().(nullDecoder)
+	switch vr.Type() {
+	case bsontype.Null:
+		n.reset()
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		n.reset()
+		return vr.ReadUndefined()
+	}
+
+	// the raw value is kept for the errors
+	t, data, err := bsonrw.Copier{}.CopyValueToBytes(vr)
+	if err != nil {
+		return
+	}
+
+	inner := reflect.New(n.innerType()).Elem()
+	dec, err := dc.LookupDecoder(inner.Type())
+	if err != nil {
+		return
+	}
+	if err = dec.DecodeValue(dc, bsonrw.NewBSONValueReader(t, data), inner); err != nil {
+		return decodeerr.BSON(inner.Type(), t, data, err)
+	}
+	n.setInner(inner)
+	return
+}
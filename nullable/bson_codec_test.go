@@ -0,0 +1,62 @@
+package nullable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNull_BSONCodecs(t *testing.T) {
+	type schema struct {
+		Name Null[string] `bson:"name"`
+		Age  Null[int32]  `bson:"age"`
+	}
+	registry := RegisterBSONCodecs(bson.NewRegistryBuilder()).Build()
+
+	t.Run("should marshal Not Null as its inner value and Null as null", func(t *testing.T) {
+		// arrange
+		s := schema{Name: Some[string]("John"), Age: None[int32]()}
+
+		// act
+		bytes, err := bson.MarshalWithRegistry(registry, s)
+
+		// assert
+		require.NoError(t, err)
+		expectedBytes, err := bson.Marshal(bson.D{{Key: "name", Value: "John"}, {Key: "age", Value: nil}})
+		require.NoError(t, err)
+		require.Equal(t, expectedBytes, bytes)
+	})
+
+	t.Run("should unmarshal a value as Not Null and null as Null", func(t *testing.T) {
+		// arrange
+		bytes, err := bson.Marshal(bson.D{{Key: "name", Value: nil}, {Key: "age", Value: int32(42)}})
+		require.NoError(t, err)
+
+		// act
+		s := schema{Name: Some[string]("John")}
+		err = bson.UnmarshalWithRegistry(registry, bytes, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, schema{Name: None[string](), Age: Some[int32](42)}, s)
+	})
+
+	t.Run("should return an error if the inner value can not be decoded", func(t *testing.T) {
+		// arrange
+		bytes, err := bson.Marshal(bson.D{{Key: "age", Value: "42"}})
+		require.NoError(t, err)
+
+		// act
+		var s schema
+		err = bson.UnmarshalWithRegistry(registry, bytes, &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "bson", decodeErr.Format)
+		require.Equal(t, reflect.TypeOf(int32(0)), decodeErr.Type)
+		require.Equal(t, `"42"`, decodeErr.Raw)
+	})
+}
@@ -0,0 +1,31 @@
+package nullable
+
+import (
+	"fmt"
+
+	"github.com/LNMMusic/optional/internal/fmtconv"
+)
+
+// String returns "Some(<value>)" or "None", with the inner value formatted as %v.
+func (n Null[T]) String() string {
+	if !n.valid {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", n.value)
+}
+
+// GoString returns the Go syntax of the Null: "nullable.Some[T](<value>)" or "nullable.None[T]()",
+// with the inner value formatted as %#v.
+func (n Null[T]) GoString() string {
+	if !n.valid {
+		return fmt.Sprintf("nullable.None[%s]()", n.innerType())
+	}
+	return fmt.Sprintf("nullable.Some[%s](%#v)", n.innerType(), n.value)
+}
+
+// Format implements fmt.Formatter:
+// - %v and %s: as String (e.g. "Some(42)"), %#v: as GoString
+// - any other verb (e.g. %5.2f, %x, %q) is passed through to the inner value, Null is printed as "None"
+func (n Null[T]) Format(f fmt.State, verb rune) {
+	fmtconv.Format(f, verb, n, n.value, n.valid)
+}
@@ -0,0 +1,42 @@
+package nullable
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Null String, GoString and Format
+func TestNull_Format(t *testing.T) {
+	type input struct {
+		format string
+		value  any
+	}
+	type output struct{ text string }
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		{title: "Format - %v some", input: input{format: "%v", value: Some(42)}, output: output{text: "Some(42)"}},
+		{title: "Format - %v null", input: input{format: "%v", value: None[int]()}, output: output{text: "None"}},
+		{title: "Format - %#v some", input: input{format: "%#v", value: Some("a")}, output: output{text: `nullable.Some[string]("a")`}},
+		{title: "Format - %#v null", input: input{format: "%#v", value: None[int]()}, output: output{text: "nullable.None[int]()"}},
+		{title: "Format - %5.2f some", input: input{format: "%5.2f", value: Some(3.14159)}, output: output{text: " 3.14"}},
+		{title: "Format - %5d null", input: input{format: "%5d", value: None[int]()}, output: output{text: " None"}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			text := fmt.Sprintf(c.input.format, c.input.value)
+
+			// assert
+			require.Equal(t, c.output.text, text)
+		})
+	}
+}
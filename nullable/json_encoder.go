@@ -0,0 +1,37 @@
+package nullable
+
+import (
+	"encoding/json"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+)
+
+// UnmarshalJSON indicates how to unmarshal a json value into a Null.
+// - null is decoded as Null
+// - any other value is decoded as Not Null (errors as *DecodeError)
+//
+// Without it, json.Unmarshal would decode Nulls with UnmarshalText (json strings only).
+func (n *Null[T]) UnmarshalJSON(data []byte) (err error) {
+	if string(data) == "null" {
+		*n = None[T]()
+		return
+	}
+
+	var v T
+	if err = decodeerr.JSON(n.innerType(), data, json.Unmarshal(data, &v)); err != nil {
+		return
+	}
+	*n = Some(v)
+	return
+}
+
+// MarshalJSON indicates how to marshal a Null into a json value.
+// Null is encoded as null, and Not Null as its inner value.
+func (n Null[T]) MarshalJSON() (data []byte, err error) {
+	if !n.valid {
+		data = []byte("null")
+		return
+	}
+	data, err = json.Marshal(n.value)
+	return
+}
@@ -0,0 +1,58 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Null UnmarshalJSON and MarshalJSON
+func TestNull_JSON(t *testing.T) {
+	type profile struct {
+		Age  Null[int]    `json:"age"`
+		Name Null[string] `json:"name"`
+	}
+
+	t.Run("JSON - marshal values and nulls", func(t *testing.T) {
+		// act
+		data, err := json.Marshal(profile{Age: Some(5), Name: None[string]()})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `{"age":5,"name":null}`, string(data))
+	})
+
+	t.Run("JSON - unmarshal values and nulls", func(t *testing.T) {
+		// act
+		p := profile{Name: Some("mary")}
+		err := json.Unmarshal([]byte(`{"age":5,"name":null}`), &p)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, profile{Age: Some(5), Name: None[string]()}, p)
+	})
+
+	t.Run("JSON - round trip", func(t *testing.T) {
+		// act
+		data, err := json.Marshal(profile{Age: Some(0), Name: Some("")})
+		require.NoError(t, err)
+		var p profile
+		err = json.Unmarshal(data, &p)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, profile{Age: Some(0), Name: Some("")}, p)
+	})
+
+	t.Run("JSON - invalid value", func(t *testing.T) {
+		// act
+		var p profile
+		err := json.Unmarshal([]byte(`{"age":"five"}`), &p)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, `"five"`, decodeErr.Raw)
+	})
+}
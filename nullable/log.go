@@ -0,0 +1,11 @@
+package nullable
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer: the inner value for Not Null, and nil (null in json logs) for Null.
+func (n Null[T]) LogValue() slog.Value {
+	if !n.valid {
+		return slog.AnyValue(nil)
+	}
+	return slog.AnyValue(n.value)
+}
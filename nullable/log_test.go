@@ -0,0 +1,29 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Null LogValue
+func TestNull_LogValue(t *testing.T) {
+	t.Run("Log - some and null", func(t *testing.T) {
+		// arrange
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+		// act
+		logger.Info("msg", "age", Some(42), "name", None[string]())
+
+		// assert
+		var attrs map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &attrs))
+		require.Equal(t, float64(42), attrs["age"])
+		require.Contains(t, attrs, "name")
+		require.Nil(t, attrs["name"])
+	})
+}
@@ -0,0 +1,29 @@
+package nullable
+
+import (
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+// nullSetter is the structs.Setter of a Null, used by the internal packages
+// (csvcodec, httpbind, envconfig, ...) that can not reach the nullDecoder.
+type nullSetter struct {
+	nullDecoder
+}
+
+// SetInner sets the Null to Not Null with the value of v.
+func (s nullSetter) SetInner(v reflect.Value) {
+	s.setInner(v)
+}
+
+// Reset sets the Null to Null.
+func (s nullSetter) Reset() {
+	s.reset()
+}
+
+func init() {
+	structs.Register(reflect.TypeOf(Null[struct{}]{}), func(v reflect.Value) structs.Setter {
+		return nullSetter{v.Addr().Interface().(nullDecoder)}
+	})
+}
@@ -0,0 +1,35 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/sqlconv"
+)
+
+// Value implements the driver.Valuer interface.
+// - Null is stored as NULL
+// - Not Null is stored as its inner value (converted following the database/sql rules)
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.value)
+}
+
+// Scan implements the sql.Scanner interface.
+// - NULL is scanned as Null
+// - any other value is scanned as Not Null, converting it following the database/sql rules
+func (n *Null[T]) Scan(src any) error {
+	if src == nil {
+		*n = None[T]()
+		return nil
+	}
+
+	var v T
+	if err := sqlconv.Assign(reflect.ValueOf(&v).Elem(), src); err != nil {
+		return err
+	}
+	*n = Some(v)
+	return nil
+}
@@ -0,0 +1,82 @@
+package nullable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNull_Value(t *testing.T) {
+	t.Run("should return nil if the Null is in a Null state", func(t *testing.T) {
+		// arrange
+		intNullable := None[int]()
+
+		// act
+		value, err := intNullable.Value()
+
+		// assert
+		require.NoError(t, err)
+		require.Nil(t, value)
+	})
+
+	t.Run("should return the converted inner value of a Not Null", func(t *testing.T) {
+		// arrange
+		intNullable := Some[int](42)
+
+		// act
+		value, err := intNullable.Value()
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, int64(42), value)
+	})
+
+	t.Run("should return an error if the inner value is not supported", func(t *testing.T) {
+		// arrange
+		sliceNullable := Some[[]int]([]int{42})
+
+		// act
+		_, err := sliceNullable.Value()
+
+		// assert
+		require.Error(t, err)
+	})
+}
+
+func TestNull_Scan(t *testing.T) {
+	t.Run("should scan NULL as Null", func(t *testing.T) {
+		// arrange
+		stringNullable := Some[string]("hello")
+
+		// act
+		err := stringNullable.Scan(nil)
+
+		// assert
+		require.NoError(t, err)
+		require.True(t, stringNullable.IsNull())
+	})
+
+	t.Run("should scan a value as Not Null", func(t *testing.T) {
+		// arrange
+		stringNullable := None[string]()
+
+		// act
+		err := stringNullable.Scan([]byte("hello"))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, Some[string]("hello"), stringNullable)
+	})
+
+	t.Run("should return an error if the value can not be converted", func(t *testing.T) {
+		// arrange
+		intNullable := None[int]()
+
+		// act
+		err := intNullable.Scan("hello")
+
+		// assert
+		require.Error(t, err)
+		require.True(t, intNullable.IsNull())
+	})
+}
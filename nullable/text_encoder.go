@@ -0,0 +1,51 @@
+package nullable
+
+import (
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/textconv"
+)
+
+// UnmarshalText indicates how to unmarshal a text into a Null.
+// The inner value is decoded with its encoding.TextUnmarshaler or strconv for the builtin kinds
+// (errors as *DecodeError). The empty string is decoded as Null.
+func (n *Null[T]) UnmarshalText(text []byte) error {
+	return n.UnmarshalTextWithNull(text, "")
+}
+
+// UnmarshalTextWithNull unmarshals a text into a Null as UnmarshalText,
+// but the text null (e.g. "NULL") is also decoded as Null.
+func (n *Null[T]) UnmarshalTextWithNull(text []byte, null string) (err error) {
+	if len(text) == 0 || string(text) == null {
+		*n = None[T]()
+		return
+	}
+
+	var v T
+	if err = textconv.Unmarshal(string(text), &v); err != nil {
+		err = decodeerr.New("text", n.innerType(), string(text), err)
+		return
+	}
+	*n = Some(v)
+	return
+}
+
+// MarshalText indicates how to marshal a Null into a text.
+// The inner value is encoded with its encoding.TextMarshaler or strconv for the builtin kinds.
+// Null is encoded as the empty string.
+func (n Null[T]) MarshalText() ([]byte, error) {
+	return n.MarshalTextWithNull("")
+}
+
+// MarshalTextWithNull marshals a Null into a text as MarshalText, but Null is encoded as the text null.
+func (n Null[T]) MarshalTextWithNull(null string) (text []byte, err error) {
+	if !n.valid {
+		return []byte(null), nil
+	}
+
+	s, err := textconv.Marshal(&n.value)
+	if err != nil {
+		return
+	}
+	text = []byte(s)
+	return
+}
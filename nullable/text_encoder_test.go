@@ -0,0 +1,64 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Null UnmarshalText and MarshalText
+func TestNull_Text(t *testing.T) {
+	t.Run("Text - round trip", func(t *testing.T) {
+		// act
+		text, err := Some(42).MarshalText()
+		require.NoError(t, err)
+		var n Null[int]
+		err = n.UnmarshalText(text)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "42", string(text))
+		require.Equal(t, Some(42), n)
+	})
+
+	t.Run("Text - null text", func(t *testing.T) {
+		// act
+		text, err := None[int]().MarshalTextWithNull("NULL")
+		require.NoError(t, err)
+		n := Some(1)
+		err = n.UnmarshalTextWithNull(text, "NULL")
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "NULL", string(text))
+		require.True(t, n.IsNull())
+	})
+
+	t.Run("Text - invalid value", func(t *testing.T) {
+		// act
+		var n Null[int]
+		err := n.UnmarshalText([]byte("forty"))
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "forty", decodeErr.Raw)
+	})
+
+	t.Run("JSON - map keys", func(t *testing.T) {
+		// arrange
+		counts := map[Null[string]]int{Some("a"): 1, Some("b"): 2}
+
+		// act
+		data, err := json.Marshal(counts)
+		require.NoError(t, err)
+		var decoded map[Null[string]]int
+		err = json.Unmarshal(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `{"a":1,"b":2}`, string(data))
+		require.Equal(t, counts, decoded)
+	})
+}
@@ -0,0 +1,73 @@
+package nullable
+
+import (
+	"encoding/xml"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/textconv"
+	"github.com/LNMMusic/optional/internal/xsi"
+)
+
+// UnmarshalXML indicates how to unmarshal a xml element into a Null.
+// - an element with xsi:nil="true" is decoded as Null
+// - any other element is decoded as Not Null (errors as *DecodeError)
+func (n *Null[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	if xsi.IsNil(start) {
+		*n = None[T]()
+		return d.Skip()
+	}
+
+	var v T
+	if err = d.DecodeElement(&v, &start); err != nil {
+		err = decodeerr.New("xml", n.innerType(), "", err)
+		return
+	}
+	*n = Some(v)
+	return
+}
+
+// MarshalXML indicates how to marshal a Null into a xml element.
+// Null is encoded as an empty element with xsi:nil="true".
+func (n Null[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	if !n.valid {
+		start = xsi.Nil(start)
+		if err = e.EncodeToken(start); err != nil {
+			return
+		}
+		return e.EncodeToken(start.End())
+	}
+	return e.EncodeElement(n.value, start)
+}
+
+// UnmarshalXMLAttr indicates how to unmarshal a xml attribute into a Null.
+// The inner value is decoded with its xml.UnmarshalerAttr, its encoding.TextUnmarshaler or strconv.
+func (n *Null[T]) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	var v T
+	if u, ok := any(&v).(xml.UnmarshalerAttr); ok {
+		err = u.UnmarshalXMLAttr(attr)
+	} else {
+		err = textconv.Unmarshal(attr.Value, &v)
+	}
+	if err != nil {
+		err = decodeerr.New("xml", n.innerType(), attr.Value, err)
+		return
+	}
+	*n = Some(v)
+	return
+}
+
+// MarshalXMLAttr indicates how to marshal a Null into a xml attribute.
+// Attributes can not be nil, so Null attributes are omitted. The inner value is encoded
+// with its xml.MarshalerAttr, its encoding.TextMarshaler or strconv.
+func (n Null[T]) MarshalXMLAttr(name xml.Name) (attr xml.Attr, err error) {
+	if !n.valid {
+		return
+	}
+	if m, ok := any(&n.value).(xml.MarshalerAttr); ok {
+		return m.MarshalXMLAttr(name)
+	}
+
+	attr.Name = name
+	attr.Value, err = textconv.Marshal(&n.value)
+	return
+}
@@ -0,0 +1,100 @@
+package nullable
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type xmlContact struct {
+	Type  Null[string] `xml:"type,attr"`
+	Phone Null[string] `xml:"phone"`
+}
+
+type xmlPerson struct {
+	XMLName  xml.Name     `xml:"person"`
+	Age      Null[int]    `xml:"age,attr"`
+	Name     Null[string] `xml:"name"`
+	Nickname Null[string] `xml:"nickname"`
+	Contacts []xmlContact `xml:"contact"`
+}
+
+// Tests for Null UnmarshalXML and UnmarshalXMLAttr
+func TestNull_UnmarshalXML(t *testing.T) {
+	t.Run("XML - values and nil elements", func(t *testing.T) {
+		// arrange
+		data := []byte(`
+<person xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" age="42">
+	<name>John</name>
+	<nickname xsi:nil="true"/>
+	<contact type="home"><phone>123</phone></contact>
+	<contact><phone xsi:nil="true"></phone></contact>
+</person>`)
+
+		// act
+		var p xmlPerson
+		err := xml.Unmarshal(data, &p)
+
+		// assert
+		require.NoError(t, err)
+		expectedPerson := xmlPerson{
+			XMLName:  xml.Name{Local: "person"},
+			Age:      Some(42),
+			Name:     Some("John"),
+			Nickname: None[string](),
+			Contacts: []xmlContact{{Type: Some("home"), Phone: Some("123")}, {}},
+		}
+		require.Equal(t, expectedPerson, p)
+	})
+
+	t.Run("XML - invalid attribute", func(t *testing.T) {
+		// act
+		var p xmlPerson
+		err := xml.Unmarshal([]byte(`<person age="forty"></person>`), &p)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "forty", decodeErr.Raw)
+	})
+}
+
+// Tests for Null MarshalXML and MarshalXMLAttr
+func TestNull_MarshalXML(t *testing.T) {
+	t.Run("XML - null elements are nil and null attributes are omitted", func(t *testing.T) {
+		// arrange
+		p := xmlPerson{Name: Some("John"), Contacts: []xmlContact{{Type: Some("home"), Phone: Some("123")}}}
+
+		// act
+		data, err := xml.Marshal(p)
+
+		// assert
+		require.NoError(t, err)
+		expectedData := `<person><name>John</name>` +
+			`<nickname xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></nickname>` +
+			`<contact type="home"><phone>123</phone></contact></person>`
+		require.Equal(t, expectedData, string(data))
+	})
+
+	t.Run("XML - round trip", func(t *testing.T) {
+		// arrange
+		p := xmlPerson{
+			XMLName:  xml.Name{Local: "person"},
+			Age:      Some(0),
+			Name:     Some("John"),
+			Nickname: None[string](),
+			Contacts: []xmlContact{{Type: Some("home")}, {Phone: Some("123")}},
+		}
+
+		// act
+		data, err := xml.Marshal(p)
+		require.NoError(t, err)
+		var decoded xmlPerson
+		err = xml.Unmarshal(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, p, decoded)
+	})
+}
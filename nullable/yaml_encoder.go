@@ -0,0 +1,39 @@
+package nullable
+
+import (
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML indicates how to unmarshal a yaml node into a Null.
+// - null (`~`, `null` or an empty value) is decoded as Null
+// - any other value is decoded as Not Null (errors as *DecodeError)
+//
+// yaml.v3 does not call the unmarshalers of struct fields for null values, it leaves them untouched.
+func (n *Null[T]) UnmarshalYAML(node *yaml.Node) (err error) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		*n = None[T]()
+		return
+	}
+
+	var v T
+	if err = node.Decode(&v); err != nil {
+		err = decodeerr.YAML(n.innerType(), node, err)
+		return
+	}
+	*n = Some(v)
+	return
+}
+
+// MarshalYAML indicates how to marshal a Null into a yaml value.
+// Null is encoded as null.
+//
+// As Option, Null does not implement IsZero, so the `omitempty` tag option of yaml.v3
+// must not be used with Nulls: every Null (Not Null included) would be omitted.
+func (n Null[T]) MarshalYAML() (v any, err error) {
+	if !n.valid {
+		return
+	}
+	v = n.value
+	return
+}
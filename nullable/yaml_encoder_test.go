@@ -0,0 +1,69 @@
+package nullable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlSchema struct {
+	Name     Null[string]   `yaml:"name"`
+	Age      Null[int]      `yaml:"age"`
+	Nickname Null[string]   `yaml:"nickname"`
+	Tags     Null[[]string] `yaml:"tags"`
+}
+
+// Tests for Null UnmarshalYAML
+func TestNull_UnmarshalYAML(t *testing.T) {
+	t.Run("YAML - values and nulls", func(t *testing.T) {
+		// arrange
+		data := []byte("name: John\nage: 42\nnickname: ~\ntags: [a]\n")
+
+		// act
+		var s yamlSchema
+		err := yaml.Unmarshal(data, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, yamlSchema{Name: Some("John"), Age: Some(42), Nickname: None[string](), Tags: Some([]string{"a"})}, s)
+	})
+
+	t.Run("YAML - invalid value", func(t *testing.T) {
+		// act
+		var s yamlSchema
+		err := yaml.Unmarshal([]byte("age: forty"), &s)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "forty", decodeErr.Raw)
+	})
+}
+
+// Tests for Null MarshalYAML
+func TestNull_MarshalYAML(t *testing.T) {
+	t.Run("YAML - values and nulls", func(t *testing.T) {
+		// act
+		data, err := yaml.Marshal(yamlSchema{Name: Some("John"), Tags: Some([]string{"a"})})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "name: John\nage: null\nnickname: null\ntags:\n    - a\n", string(data))
+	})
+
+	t.Run("YAML - round trip", func(t *testing.T) {
+		// arrange
+		s := yamlSchema{Name: Some("John"), Age: Some(0), Nickname: None[string](), Tags: Some([]string{})}
+
+		// act
+		data, err := yaml.Marshal(s)
+		require.NoError(t, err)
+		var decoded yamlSchema
+		err = yaml.Unmarshal(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, s, decoded)
+	})
+}
@@ -0,0 +1,98 @@
+package optional
+
+import (
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+// optionReflector gives non generic access to any *Option[T].
+// It is used by the reflection based features of the package (filters, codecs, ...)
+// where the type parameter of the option is not known at compile time.
+type optionReflector interface {
+	// IsSome returns true if the option is a Some value.
+	IsSome() bool
+	// inner returns the inner value of the option as an interface.
+	// It must only be called on Some values.
+	inner() any
+	// innerValue returns the inner value of the option as an addressable reflect.Value of type T.
+	// It must only be called on Some values.
+	innerValue() reflect.Value
+	// innerType returns the type T of the option.
+	innerType() reflect.Type
+	// setInner sets the option to Some with v, a reflect.Value of type T.
+	setInner(v reflect.Value)
+	// reset sets the option to None.
+	reset()
+}
+
+// inner returns the inner value of a Some as an interface.
+func (o *Option[T]) inner() any {
+	return *o.value
+}
+
+// innerValue returns the inner value of a Some as an addressable reflect.Value.
+func (o *Option[T]) innerValue() reflect.Value {
+	return reflect.ValueOf(o.value).Elem()
+}
+
+// innerType returns the type of the inner value.
+func (o *Option[T]) innerType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// setInner sets the option to Some with the value of v.
+func (o *Option[T]) setInner(v reflect.Value) {
+	var value T
+	reflect.ValueOf(&value).Elem().Set(v)
+	o.value = &value
+}
+
+// reset sets the option to None.
+func (o *Option[T]) reset() {
+	o.value = nil
+}
+
+// asOptionReflector returns the optionReflector of v if v holds an Option.
+// Non addressable values are copied so the pointer methods can be reached.
+func asOptionReflector(v reflect.Value) (r optionReflector, ok bool) {
+	if !v.CanAddr() {
+		cp := reflect.New(v.Type())
+		cp.Elem().Set(v)
+		v = cp.Elem()
+	}
+
+	r, ok = v.Addr().Interface().(optionReflector)
+	return
+}
+
+// optionSetter is the structs.Setter of an Option, used by the internal packages
+// (csvcodec, httpbind, envconfig, ...) that can not reach the optionReflector.
+type optionSetter struct {
+	optionReflector
+}
+
+// SetInner sets the option to Some with the value of v.
+func (s optionSetter) SetInner(v reflect.Value) {
+	s.setInner(v)
+}
+
+// Reset sets the option to None.
+func (s optionSetter) Reset() {
+	s.reset()
+}
+
+func init() {
+	option := func(v reflect.Value) structs.Setter {
+		r, _ := asOptionReflector(v)
+		return optionSetter{r}
+	}
+	// the wrappers of Option (StringOption, StrictOption, ...) are set through their embedded Option
+	embedded := func(v reflect.Value) structs.Setter {
+		return option(v.FieldByName("Option"))
+	}
+	structs.Register(reflect.TypeOf(Option[struct{}]{}), option)
+	structs.Register(reflect.TypeOf(StringOption[int]{}), embedded)
+	structs.Register(reflect.TypeOf(StrictOption[struct{}]{}), embedded)
+	structs.Register(reflect.TypeOf(SecretOption[struct{}]{}), embedded)
+}
@@ -0,0 +1,89 @@
+package optional
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/structs"
+	"go.mongodb.org/mongo-driver/bson"
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalJSONReset unmarshals data into v as json.Unmarshal, but first resets every Option
+// reachable from v to None (see ResetNone).
+// It must be used when decoding into a reused value (e.g. a pooled DTO): json.Unmarshal leaves
+// the fields of the keys absent from data untouched, so previous Some values would leak.
+func UnmarshalJSONReset(data []byte, v any) (err error) {
+	ResetNone(v)
+	err = json.Unmarshal(data, v)
+	return
+}
+
+// UnmarshalBSONReset unmarshals data into v as bson.Unmarshal, but first resets every Option
+// reachable from v to None (see ResetNone).
+// It must be used when decoding into a reused value (e.g. an existing document): bson.Unmarshal leaves
+// the fields of the keys absent from data untouched, so previous Some values would leak.
+func UnmarshalBSONReset(data []byte, v any) (err error) {
+	ResetNone(v)
+	err = bson.Unmarshal(data, v)
+	return
+}
+
+// UnmarshalYAMLReset unmarshals data into v as yaml.Unmarshal, but first resets every Option
+// reachable from v to None (see ResetNone).
+// It must be used when decoding into a reused value: yaml.Unmarshal leaves the fields of the
+// absent keys and of the null values untouched, so previous Some values would leak.
+func UnmarshalYAMLReset(data []byte, v any) (err error) {
+	ResetNone(v)
+	err = yaml.Unmarshal(data, v)
+	return
+}
+
+// ResetNone resets every Option reachable from the pointer v to None.
+// - exported struct fields, non nil pointers and slice and array elements are walked
+// - the inner values of Options are not walked, as they are dropped
+// - nullable.Null values are also reset (to Null), so no previous value is left
+func ResetNone(v any) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return
+	}
+	resetNone(rv.Elem())
+}
+
+// resetNone resets the Options of the addressable value rv.
+func resetNone(rv reflect.Value) {
+	// unexported fields can not be set (but the exported fields of unexported embedded structs can)
+	if !rv.CanSet() {
+		if rv.Kind() == reflect.Struct {
+			for i := 0; i < rv.NumField(); i++ {
+				resetNone(rv.Field(i))
+			}
+		}
+		return
+	}
+
+	if opt, ok := rv.Addr().Interface().(optionReflector); ok {
+		opt.reset()
+		return
+	}
+	if structs.IsNull(rv.Type()) {
+		rv.Set(reflect.Zero(rv.Type()))
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if !rv.IsNil() {
+			resetNone(rv.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			resetNone(rv.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			resetNone(rv.Index(i))
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type resetAddress struct {
+	Street Option[string] `json:"street" bson:"street"`
+}
+
+type resetMeta struct {
+	Source Option[string] `json:"source" bson:"source"`
+}
+
+type resetSchema struct {
+	Name     Option[string]        `json:"name" bson:"name"`
+	Age      Option[int]           `json:"age" bson:"age"`
+	Nickname nullable.Null[string] `json:"-" bson:"-"`
+	Address  resetAddress          `json:"address" bson:"address"`
+	Previous *resetAddress         `json:"previous" bson:"previous"`
+	Others   []resetAddress        `json:"others" bson:"others"`
+	Inner    Option[resetAddress]  `json:"inner" bson:"inner"`
+	Plain    string                `json:"plain" bson:"plain"`
+	resetMeta
+}
+
+// resetNullLike has an IsNull method but is not a nullable.Null.
+type resetNullLike struct {
+	Street string
+	City   Option[string]
+}
+
+func (a resetNullLike) IsNull() bool {
+	return a.Street == ""
+}
+
+func newStaleSchema() resetSchema {
+	return resetSchema{
+		Name:      Some("stale"),
+		Age:       Some(99),
+		Nickname:  nullable.Some("stale"),
+		Address:   resetAddress{Street: Some("stale")},
+		Previous:  &resetAddress{Street: Some("stale")},
+		Others:    []resetAddress{{Street: Some("stale")}},
+		Inner:     Some(resetAddress{Street: Some("stale")}),
+		Plain:     "plain",
+		resetMeta: resetMeta{Source: Some("stale")},
+	}
+}
+
+// Tests for ResetNone
+func TestResetNone(t *testing.T) {
+	t.Run("succeed to reset - every option is none", func(t *testing.T) {
+		// arrange
+		s := newStaleSchema()
+
+		// act
+		ResetNone(&s)
+
+		// assert
+		expectedSchema := resetSchema{
+			Nickname: nullable.None[string](),
+			Previous: &resetAddress{},
+			Others:   []resetAddress{{}},
+			Plain:    "plain",
+		}
+		require.Equal(t, expectedSchema, s)
+	})
+
+	t.Run("succeed to reset - non pointer values are ignored", func(t *testing.T) {
+		// arrange
+		s := newStaleSchema()
+
+		// act
+		ResetNone(s)
+
+		// assert
+		require.Equal(t, newStaleSchema(), s)
+	})
+
+	t.Run("succeed to reset - values with an IsNull method are walked", func(t *testing.T) {
+		// arrange
+		s := resetNullLike{Street: "Main", City: Some("Lima")}
+
+		// act
+		ResetNone(&s)
+
+		// assert
+		require.Equal(t, resetNullLike{Street: "Main", City: None[string]()}, s)
+	})
+}
+
+// Tests for UnmarshalJSONReset
+func TestUnmarshalJSONReset(t *testing.T) {
+	t.Run("succeed to unmarshal - missing keys are reset to none", func(t *testing.T) {
+		// arrange
+		s := newStaleSchema()
+		data := []byte(`{"age": 20, "inner": {}, "others": [{"street": "new"}]}`)
+
+		// act
+		err := UnmarshalJSONReset(data, &s)
+
+		// assert
+		expectedSchema := resetSchema{
+			Age:      Some(20),
+			Previous: &resetAddress{},
+			Others:   []resetAddress{{Street: Some("new")}},
+			Inner:    Some(resetAddress{}),
+			Plain:    "plain",
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, s)
+	})
+
+	t.Run("json.Unmarshal leaves the missing keys", func(t *testing.T) {
+		// arrange
+		type schema struct {
+			Field Option[string] `json:"field"`
+			Other Option[string] `json:"other"`
+		}
+		s := schema{Field: Some("stale"), Other: Some("stale")}
+		data := []byte(`{"field": "new"}`)
+
+		// act
+		err := json.Unmarshal(data, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, schema{Field: Some("new"), Other: Some("stale")}, s)
+	})
+
+	t.Run("fail to unmarshal - invalid json", func(t *testing.T) {
+		// arrange
+		s := newStaleSchema()
+
+		// act
+		err := UnmarshalJSONReset([]byte(`{`), &s)
+
+		// assert
+		require.Error(t, err)
+	})
+}
+
+// Tests for UnmarshalBSONReset
+func TestUnmarshalBSONReset(t *testing.T) {
+	t.Run("succeed to unmarshal - missing keys are reset to none", func(t *testing.T) {
+		// arrange
+		s := newStaleSchema()
+		bytes, err := bson.Marshal(bson.M{"name": "new"})
+		require.NoError(t, err)
+
+		// act
+		err = UnmarshalBSONReset(bytes, &s)
+
+		// assert
+		expectedSchema := resetSchema{
+			Name:     Some("new"),
+			Nickname: nullable.None[string](),
+			Previous: &resetAddress{},
+			Others:   []resetAddress{{}},
+			Plain:    "plain",
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, s)
+	})
+
+	t.Run("bson.Unmarshal leaves the missing keys", func(t *testing.T) {
+		// arrange
+		type schema struct {
+			Field Option[string] `bson:"field"`
+			Other Option[string] `bson:"other"`
+		}
+		s := schema{Field: Some("stale"), Other: Some("stale")}
+		bytes, err := bson.Marshal(bson.M{"field": "new"})
+		require.NoError(t, err)
+
+		// act
+		err = bson.Unmarshal(bytes, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, schema{Field: Some("new"), Other: Some("stale")}, s)
+	})
+}
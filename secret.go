@@ -0,0 +1,108 @@
+package optional
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+
+	"github.com/LNMMusic/optional/internal/fmtconv"
+)
+
+// redacted replaces the inner value of a SecretOption in logs and prints.
+const redacted = "<redacted>"
+
+// SecretOption is an Option for sensitive values (passwords, tokens, ...) that never shows its
+// inner value in logs and prints: Some is shown as "Some(<redacted>)" by String, Format (any verb)
+// and LogValue.
+//
+// The text encodings (json, text, yaml and xml) also redact Some as "<redacted>", so the value does
+// not leak when a struct holding the SecretOption is logged (e.g. with slog.NewJSONHandler) or dumped.
+// Use Reveal to encode the inner value. The storage encodings (sql, bson, binary and gob) and all the
+// decodings are the ones of Option. The methods of the embedded Option (IsSome, Unwrap, ...) are promoted.
+type SecretOption[T any] struct {
+	Option[T]
+}
+
+// SomeSecret returns a SecretOption with a Some value.
+func SomeSecret[T any](value T) SecretOption[T] {
+	return SecretOption[T]{Option: Some(value)}
+}
+
+// NoneSecret returns a SecretOption with a None value.
+func NoneSecret[T any]() SecretOption[T] {
+	return SecretOption[T]{Option: None[T]()}
+}
+
+// Reveal returns the Option with the inner value, e.g. to encode it with json.Marshal.
+func (o SecretOption[T]) Reveal() Option[T] {
+	return o.Option
+}
+
+// String returns "Some(<redacted>)" or "None".
+func (o SecretOption[T]) String() string {
+	if o.value == nil {
+		return "None"
+	}
+	return "Some(" + redacted + ")"
+}
+
+// GoString returns "optional.SomeSecret[T](<redacted>)" or "optional.NoneSecret[T]()".
+func (o SecretOption[T]) GoString() string {
+	if o.value == nil {
+		return fmt.Sprintf("optional.NoneSecret[%s]()", o.innerType())
+	}
+	return fmt.Sprintf("optional.SomeSecret[%s](%s)", o.innerType(), redacted)
+}
+
+// Format implements fmt.Formatter as Option, but no verb shows the inner value:
+// %#v is formatted as GoString and any other verb as String.
+func (o SecretOption[T]) Format(f fmt.State, verb rune) {
+	// the inner value is never passed, so every verb falls back to String
+	fmtconv.Format(f, verb, o, nil, false)
+}
+
+// LogValue implements slog.LogValuer: "Some(<redacted>)" for Some, and nil (null in json logs) for None.
+func (o SecretOption[T]) LogValue() slog.Value {
+	if o.value == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.StringValue(o.String())
+}
+
+// MarshalJSON encodes Some as "<redacted>" and None as null.
+func (o SecretOption[T]) MarshalJSON() ([]byte, error) {
+	return o.redact().MarshalJSON()
+}
+
+// MarshalText encodes Some as "<redacted>" and None as the empty string.
+func (o SecretOption[T]) MarshalText() ([]byte, error) {
+	return o.redact().MarshalText()
+}
+
+// MarshalTextWithNone encodes Some as "<redacted>" and None as the text none.
+func (o SecretOption[T]) MarshalTextWithNone(none string) ([]byte, error) {
+	return o.redact().MarshalTextWithNone(none)
+}
+
+// MarshalYAML encodes Some as "<redacted>" and None as null.
+func (o SecretOption[T]) MarshalYAML() (any, error) {
+	return o.redact().MarshalYAML()
+}
+
+// MarshalXML encodes Some as an element with "<redacted>" and omits None.
+func (o SecretOption[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return o.redact().MarshalXML(e, start)
+}
+
+// MarshalXMLAttr encodes Some as an attribute with "<redacted>" and omits None.
+func (o SecretOption[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return o.redact().MarshalXMLAttr(name)
+}
+
+// redact returns the Option encoded in place of the SecretOption: Some("<redacted>") or None.
+func (o SecretOption[T]) redact() Option[string] {
+	if o.value == nil {
+		return None[string]()
+	}
+	return Some(redacted)
+}
@@ -0,0 +1,168 @@
+package optional
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// Tests for SecretOption redaction
+func TestSecretOption_Redaction(t *testing.T) {
+	type input struct {
+		format string
+		value  any
+	}
+	type output struct{ text string }
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		{title: "Format - %v some", input: input{format: "%v", value: SomeSecret("hunter2")}, output: output{text: "Some(<redacted>)"}},
+		{title: "Format - %v none", input: input{format: "%v", value: NoneSecret[string]()}, output: output{text: "None"}},
+		{title: "Format - %s some", input: input{format: "%s", value: SomeSecret("hunter2")}, output: output{text: "Some(<redacted>)"}},
+		{title: "Format - %q some", input: input{format: "%q", value: SomeSecret("hunter2")}, output: output{text: "Some(<redacted>)"}},
+		{title: "Format - %x some", input: input{format: "%x", value: SomeSecret(255)}, output: output{text: "Some(<redacted>)"}},
+		{title: "Format - %#v some", input: input{format: "%#v", value: SomeSecret("hunter2")}, output: output{text: "optional.SomeSecret[string](<redacted>)"}},
+		{title: "Format - %#v none", input: input{format: "%#v", value: NoneSecret[string]()}, output: output{text: "optional.NoneSecret[string]()"}},
+		{title: "Format - %+v in struct", input: input{format: "%+v", value: struct{ Password SecretOption[string] }{Password: SomeSecret("hunter2")}}, output: output{text: "{Password:Some(<redacted>)}"}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			text := fmt.Sprintf(c.input.format, c.input.value)
+
+			// assert
+			require.Equal(t, c.output.text, text)
+		})
+	}
+
+	t.Run("Log - some and none", func(t *testing.T) {
+		// act
+		attrs := logJSON(t, "password", SomeSecret("hunter2"), "token", NoneSecret[string]())
+
+		// assert
+		require.Equal(t, map[string]any{"password": "Some(<redacted>)", "token": nil}, attrs)
+	})
+
+	t.Run("Log - secret inside an option", func(t *testing.T) {
+		// act
+		attrs := logJSON(t, "password", Some(SomeSecret("hunter2")))
+
+		// assert
+		require.Equal(t, map[string]any{"password": "Some(<redacted>)"}, attrs)
+	})
+
+	t.Run("Log - secret inside a struct", func(t *testing.T) {
+		// arrange
+		type credentials struct {
+			User     Option[string]       `json:"user"`
+			Password SecretOption[string] `json:"password"`
+			Token    SecretOption[string] `json:"token"`
+		}
+
+		// act
+		attrs := logJSON(t, "credentials", credentials{User: Some("mary"), Password: SomeSecret("hunter2"), Token: NoneSecret[string]()})
+
+		// assert
+		require.Equal(t, map[string]any{"credentials": map[string]any{"user": "mary", "password": "<redacted>", "token": nil}}, attrs)
+	})
+}
+
+// Tests for SecretOption json encoding
+func TestSecretOption_JSON(t *testing.T) {
+	type credentials struct {
+		Password SecretOption[string] `json:"password"`
+	}
+
+	t.Run("JSON - decoded as is and encoded redacted", func(t *testing.T) {
+		// act
+		var c credentials
+		err := json.Unmarshal([]byte(`{"password": "hunter2"}`), &c)
+		require.NoError(t, err)
+		data, err := json.Marshal(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "hunter2", c.Password.Unwrap())
+		require.JSONEq(t, `{"password":"<redacted>"}`, string(data))
+	})
+
+	t.Run("JSON - none", func(t *testing.T) {
+		// act
+		data, err := json.Marshal(credentials{Password: NoneSecret[string]()})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `{"password":null}`, string(data))
+	})
+
+	t.Run("JSON - reveal", func(t *testing.T) {
+		// act
+		data, err := json.Marshal(SomeSecret("hunter2").Reveal())
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `"hunter2"`, string(data))
+	})
+}
+
+// Tests for SecretOption text encodings
+func TestSecretOption_TextEncodings(t *testing.T) {
+	type credentials struct {
+		XMLName  xml.Name             `xml:"credentials" yaml:"-"`
+		Password SecretOption[string] `xml:"password" yaml:"password"`
+		Token    SecretOption[string] `xml:"token,attr" yaml:"token"`
+	}
+	c := credentials{Password: SomeSecret("hunter2"), Token: SomeSecret("abc")}
+
+	t.Run("Text - some and none", func(t *testing.T) {
+		// act
+		some, err1 := SomeSecret("hunter2").MarshalText()
+		none, err2 := NoneSecret[string]().MarshalText()
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Equal(t, "<redacted>", string(some))
+		require.Equal(t, "", string(none))
+	})
+
+	t.Run("Text - some and none with a none text", func(t *testing.T) {
+		// act
+		some, err1 := SomeSecret("hunter2").MarshalTextWithNone("-")
+		none, err2 := NoneSecret[string]().MarshalTextWithNone("-")
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Equal(t, "<redacted>", string(some))
+		require.Equal(t, "-", string(none))
+	})
+
+	t.Run("YAML - struct", func(t *testing.T) {
+		// act
+		data, err := yaml.Marshal(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "password: <redacted>\ntoken: <redacted>\n", string(data))
+	})
+
+	t.Run("XML - struct", func(t *testing.T) {
+		// act
+		data, err := xml.Marshal(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `<credentials token="&lt;redacted&gt;"><password>&lt;redacted&gt;</password></credentials>`, string(data))
+	})
+}
@@ -0,0 +1,54 @@
+package optional
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/LNMMusic/optional/internal/sentinel"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+// MarshalJSON marshals v as json.Marshal, but the None/Null fields with null sentinels are
+// encoded as their first sentinel instead of null.
+//
+// Null sentinels are the legacy values that mean "no value", set on Option (and nullable.Null)
+// fields with the `optional` tag:
+//
+//	type Legacy struct {
+//		Age   Option[int]       `json:"age" optional:"null=-1,null=N/A"`
+//		Birth Option[time.Time] `json:"birth" optional:"null=0000-00-00"`
+//	}
+//
+// - decoding (UnmarshalJSON, UnmarshalJSONLenient, UnmarshalBSON): a value equal to any sentinel is None/Null
+// - encoding (MarshalJSON, MarshalBSON): None/Null is the first sentinel, as a value of T if it can
+// be parsed as T (-1 for an Option[int]), as a string otherwise
+//
+// The sentinels are only known by the functions of this package, json.Marshal and json.Unmarshal
+// ignore them. Fields omitted by omitempty are still omitted.
+func MarshalJSON(v any) (data []byte, err error) {
+	if data, err = json.Marshal(v); err != nil {
+		return
+	}
+	data = sentinel.JSON(data, reflect.TypeOf(v), true)
+	return
+}
+
+// MarshalBSON marshals v as bson.Marshal, but the None/Null fields with null sentinels are
+// encoded as their first sentinel instead of null (see MarshalJSON).
+// The Option and nullable.Null codecs are registered (see RegisterBSONCodecs), so Null is encoded
+// as null and Not Null as its inner value.
+func MarshalBSON(v any) (data []byte, err error) {
+	if data, err = bson.MarshalWithRegistry(bsonRegistry(), v); err != nil {
+		return
+	}
+	data = sentinel.BSON(data, reflect.TypeOf(v), true)
+	return
+}
+
+// bsonRegistry returns the registry of MarshalBSON and UnmarshalBSON: the default one with the
+// Option and nullable.Null codecs, as the default registry does not know how to encode Nulls.
+var bsonRegistry = sync.OnceValue(func() *bsoncodec.Registry {
+	return RegisterBSONCodecs(bson.NewRegistryBuilder()).Build()
+})
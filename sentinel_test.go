@@ -0,0 +1,229 @@
+package optional
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type legacyItem struct {
+	Code Option[string] `json:"code" bson:"code" optional:"null=-"`
+}
+
+type legacySchema struct {
+	Age   Option[int]        `json:"age" bson:"age" optional:"null=-1,null=N/A"`
+	Birth Option[time.Time]  `json:"birth" bson:"birth" optional:"null=0000-00-00"`
+	Plain Option[int]        `json:"plain" bson:"plain"`
+	Items []legacyItem       `json:"items" bson:"items"`
+	Inner Option[legacyItem] `json:"inner" bson:"inner"`
+}
+
+type legacyNulls struct {
+	Age  nullable.Null[int]    `json:"age" bson:"age" optional:"null=-1"`
+	Code nullable.Null[string] `json:"code" bson:"code" optional:"null=N/A"`
+}
+
+// Tests for the null sentinels of the json decoding and encoding
+func TestSentinel_JSON(t *testing.T) {
+	t.Run("JSON - sentinels are decoded as none", func(t *testing.T) {
+		// arrange
+		data := []byte(`{"age": "N/A", "birth": "0000-00-00", "plain": 1, "items": [{"code": "-"}, {"code": "a"}], "inner": {"code": "-"}}`)
+
+		// act
+		var s legacySchema
+		err := UnmarshalJSON(data, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, s.Age.IsSome())
+		require.False(t, s.Birth.IsSome())
+		require.Equal(t, 1, s.Plain.Unwrap())
+		require.Equal(t, []legacyItem{{Code: None[string]()}, {Code: Some("a")}}, s.Items)
+		require.Equal(t, legacyItem{Code: None[string]()}, s.Inner.Unwrap())
+	})
+
+	t.Run("JSON - numeric sentinels", func(t *testing.T) {
+		// act
+		var s legacySchema
+		err := UnmarshalJSON([]byte(`{"age": -1}`), &s)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, s.Age.IsSome())
+	})
+
+	t.Run("JSON - other values are decoded as usual", func(t *testing.T) {
+		// act
+		var s legacySchema
+		err := UnmarshalJSON([]byte(`{"age": 20, "birth": "2024-01-02T00:00:00Z"}`), &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 20, s.Age.Unwrap())
+		require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), s.Birth.Unwrap())
+	})
+
+	t.Run("JSON - lenient decoding", func(t *testing.T) {
+		// act
+		var s legacySchema
+		report, err := UnmarshalJSONLenient([]byte(`{"age": "N/A", "plain": "N/A"}`), &s)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, s.Age.IsSome())
+		require.Len(t, report, 1)
+		require.Equal(t, "plain", report[0].Path)
+	})
+
+	t.Run("JSON - none is encoded as the first sentinel", func(t *testing.T) {
+		// arrange
+		s := legacySchema{Items: []legacyItem{{}}, Inner: Some(legacyItem{})}
+
+		// act
+		data, err := MarshalJSON(s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `{"age":-1,"birth":"0000-00-00","plain":null,"items":[{"code":"-"}],"inner":{"code":"-"}}`, string(data))
+	})
+
+	t.Run("JSON - round trip", func(t *testing.T) {
+		// arrange
+		s := legacySchema{Age: Some(20), Items: []legacyItem{{Code: Some("a")}, {}}}
+
+		// act
+		data, err := MarshalJSON(&s)
+		require.NoError(t, err)
+		var decoded legacySchema
+		err = UnmarshalJSON(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, s, decoded)
+	})
+
+	t.Run("JSON - wrappers of Option", func(t *testing.T) {
+		// arrange
+		type wrappers struct {
+			ID    StringOption[int]    `json:"id" optional:"null=-1"`
+			Token SecretOption[string] `json:"token" optional:"null=-"`
+		}
+
+		// act
+		var decoded wrappers
+		err := UnmarshalJSON([]byte(`{"id": "-1", "token": "-"}`), &decoded)
+		require.NoError(t, err)
+		data, err := MarshalJSON(wrappers{})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, wrappers{ID: NoneString[int](), Token: NoneSecret[string]()}, decoded)
+		require.Equal(t, `{"id":-1,"token":"-"}`, string(data))
+	})
+
+	t.Run("JSON - null fields", func(t *testing.T) {
+		// arrange
+		s := legacyNulls{Age: nullable.None[int](), Code: nullable.Some("a")}
+
+		// act
+		data, err := MarshalJSON(s)
+		require.NoError(t, err)
+		decoded := legacyNulls{Age: nullable.Some(1)}
+		err = UnmarshalJSON(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, `{"age":-1,"code":"a"}`, string(data))
+		require.Equal(t, s, decoded)
+	})
+
+	t.Run("BSON - null fields", func(t *testing.T) {
+		// arrange
+		s := legacyNulls{Age: nullable.None[int](), Code: nullable.Some("a")}
+
+		// act
+		data, err := MarshalBSON(s)
+		require.NoError(t, err)
+		decoded := legacyNulls{Age: nullable.Some(1)}
+		err = UnmarshalBSON(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		var doc bson.D
+		require.NoError(t, bson.Unmarshal(data, &doc))
+		require.Equal(t, bson.D{{Key: "age", Value: int32(-1)}, {Key: "code", Value: "a"}}, doc)
+		require.Equal(t, s, decoded)
+	})
+}
+
+// Tests for the null sentinels of the bson decoding and encoding
+func TestSentinel_BSON(t *testing.T) {
+	t.Run("BSON - sentinels are decoded as none", func(t *testing.T) {
+		// arrange
+		data, err := bson.Marshal(bson.M{"age": int32(-1), "birth": "0000-00-00", "items": bson.A{bson.M{"code": "-"}}})
+		require.NoError(t, err)
+
+		// act
+		var s legacySchema
+		err = UnmarshalBSON(data, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, s.Age.IsSome())
+		require.False(t, s.Birth.IsSome())
+		require.Equal(t, []legacyItem{{Code: None[string]()}}, s.Items)
+	})
+
+	t.Run("BSON - none is encoded as the first sentinel", func(t *testing.T) {
+		// act
+		data, err := MarshalBSON(legacySchema{Items: []legacyItem{{}}})
+
+		// assert
+		require.NoError(t, err)
+		var doc bson.D
+		require.NoError(t, bson.Unmarshal(data, &doc))
+		require.Equal(t, bson.D{
+			{Key: "age", Value: int32(-1)},
+			{Key: "birth", Value: "0000-00-00"},
+			{Key: "plain", Value: nil},
+			{Key: "items", Value: bson.A{bson.D{{Key: "code", Value: "-"}}}},
+			{Key: "inner", Value: nil},
+		}, doc)
+	})
+
+	t.Run("BSON - round trip", func(t *testing.T) {
+		// arrange
+		s := legacySchema{Age: Some(20), Items: []legacyItem{{}}}
+
+		// act
+		data, err := MarshalBSON(s)
+		require.NoError(t, err)
+		var decoded legacySchema
+		err = UnmarshalBSON(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, s, decoded)
+	})
+
+	t.Run("BSON - null fields", func(t *testing.T) {
+		// arrange
+		s := legacyNulls{Age: nullable.None[int](), Code: nullable.Some("a")}
+
+		// act
+		data, err := MarshalBSON(s)
+		require.NoError(t, err)
+		decoded := legacyNulls{Age: nullable.Some(1)}
+		err = UnmarshalBSON(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		var doc bson.D
+		require.NoError(t, bson.Unmarshal(data, &doc))
+		require.Equal(t, bson.D{{Key: "age", Value: int32(-1)}, {Key: "code", Value: "a"}}, doc)
+		require.Equal(t, s, decoded)
+	})
+}
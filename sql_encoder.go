@@ -0,0 +1,36 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"github.com/LNMMusic/optional/internal/sqlconv"
+)
+
+// Value implements the driver.Valuer interface.
+// - None is stored as NULL
+// - Some is stored as its inner value (converted following the database/sql rules)
+func (o Option[T]) Value() (v driver.Value, err error) {
+	if o.value == nil {
+		return
+	}
+	v, err = driver.DefaultParameterConverter.ConvertValue(*o.value)
+	return
+}
+
+// Scan implements the sql.Scanner interface.
+// - NULL is scanned as None
+// - any other value is scanned as Some, converting it following the database/sql rules
+func (o *Option[T]) Scan(src any) (err error) {
+	if src == nil {
+		o.value = nil
+		return
+	}
+
+	var v T
+	if err = sqlconv.Assign(reflect.ValueOf(&v).Elem(), src); err != nil {
+		return
+	}
+	o.value = &v
+	return
+}
@@ -0,0 +1,148 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Value
+func TestOption_Value(t *testing.T) {
+	type input struct{ value driver.Valuer }
+	type output struct {
+		value driver.Value
+		err   bool
+	}
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		{title: "SQL - string", input: input{value: Some("hello")}, output: output{value: "hello"}},
+		{title: "SQL - string null", input: input{value: None[string]()}, output: output{value: nil}},
+		{title: "SQL - int", input: input{value: Some(1)}, output: output{value: int64(1)}},
+		{title: "SQL - float64", input: input{value: Some(1.5)}, output: output{value: 1.5}},
+		{title: "SQL - bool", input: input{value: Some(true)}, output: output{value: true}},
+		{title: "SQL - nested valuer", input: input{value: Some(Some(1))}, output: output{value: int64(1)}},
+		{title: "SQL - nested valuer null", input: input{value: Some(None[int]())}, output: output{value: nil}},
+		{title: "SQL - unsupported type", input: input{value: Some([]string{"hello"})}, output: output{err: true}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			value, err := c.input.value.Value()
+
+			// assert
+			if c.output.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.output.value, value)
+		})
+	}
+}
+
+// Tests for Scan
+func TestOption_Scan(t *testing.T) {
+	t.Run("SQL - string", func(t *testing.T) {
+		// arrange
+		option := None[string]()
+
+		// act
+		err := option.Scan([]byte("hello"))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, Some("hello"), option)
+	})
+
+	t.Run("SQL - int", func(t *testing.T) {
+		// arrange
+		option := None[int]()
+
+		// act
+		err := option.Scan(int64(42))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, Some(42), option)
+	})
+
+	t.Run("SQL - int from text", func(t *testing.T) {
+		// arrange
+		option := None[int32]()
+
+		// act
+		err := option.Scan("42")
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, Some[int32](42), option)
+	})
+
+	t.Run("SQL - float64", func(t *testing.T) {
+		// arrange
+		option := None[float64]()
+
+		// act
+		err := option.Scan(int64(1))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, Some(1.0), option)
+	})
+
+	t.Run("SQL - bool", func(t *testing.T) {
+		// arrange
+		option := None[bool]()
+
+		// act
+		err := option.Scan(int64(1))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, Some(true), option)
+	})
+
+	t.Run("SQL - null", func(t *testing.T) {
+		// arrange
+		option := Some("hello")
+
+		// act
+		err := option.Scan(nil)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, option.IsSome())
+	})
+
+	t.Run("SQL - overflow", func(t *testing.T) {
+		// arrange
+		option := None[int8]()
+
+		// act
+		err := option.Scan(int64(1000))
+
+		// assert
+		require.Error(t, err)
+		require.False(t, option.IsSome())
+	})
+
+	t.Run("SQL - invalid syntax", func(t *testing.T) {
+		// arrange
+		option := Some(1)
+
+		// act
+		err := option.Scan("one")
+
+		// assert
+		require.Error(t, err)
+		require.Equal(t, Some(1), option)
+	})
+}
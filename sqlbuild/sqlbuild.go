@@ -0,0 +1,177 @@
+// Package sqlbuild builds parameterised SQL statements from structs with
+// optional.Option and nullable.Null fields.
+//
+// It is the SQL counterpart of the JSON support of Option:
+// - None fields are absent, so they are not touched (UPDATE) or take their DEFAULT (INSERT)
+// - Null fields are present with a NULL value
+// - any other field is always present with its value
+//
+// Columns are mapped with the `db` tag (or the lowercased field name), `db:"-"` skips a field.
+package sqlbuild
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+var (
+	ErrNotStruct = errors.New("sqlbuild: source must be a struct")
+	ErrNoColumns = errors.New("sqlbuild: no columns to set")
+)
+
+// Placeholder returns the placeholder of the n-th argument (starting at 1) of a statement.
+// Custom placeholder styles can be plugged in by defining a new Placeholder.
+type Placeholder func(n int) string
+
+var (
+	// Question is the placeholder style of MySQL and SQLite: ?
+	Question Placeholder = func(n int) string { return "?" }
+	// Dollar is the placeholder style of PostgreSQL: $1
+	Dollar Placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+	// AtP is the placeholder style of SQL Server: @p1
+	AtP Placeholder = func(n int) string { return "@p" + strconv.Itoa(n) }
+)
+
+// column kinds
+const (
+	columnAbsent = iota
+	columnNull
+	columnValue
+)
+
+// column is a column of a statement with its value.
+type column struct {
+	name  string
+	kind  int
+	value driver.Value
+}
+
+// Update builds an UPDATE statement that only sets the present fields of src.
+// - None fields are skipped
+// - Null fields (and Some(nullable.None)) are set to NULL
+//
+// The statement has no WHERE clause, it is up to the caller to append it.
+func Update(table string, src any, placeholder Placeholder) (query string, args []any, err error) {
+	cols, err := columns(src)
+	if err != nil {
+		return
+	}
+
+	var sets []string
+	for _, c := range cols {
+		switch c.kind {
+		case columnNull:
+			sets = append(sets, c.name+" = NULL")
+		case columnValue:
+			args = append(args, c.value)
+			sets = append(sets, c.name+" = "+placeholder(len(args)))
+		}
+	}
+	if len(sets) == 0 {
+		err = ErrNoColumns
+		return
+	}
+
+	query = "UPDATE " + table + " SET " + strings.Join(sets, ", ")
+	return
+}
+
+// Insert builds an INSERT statement with all the fields of src.
+// - None fields take the DEFAULT value of the column
+// - Null fields (and Some(nullable.None)) are inserted as NULL
+//
+// Note that some databases (e.g. SQLite) do not support DEFAULT in a VALUES list.
+func Insert(table string, src any, placeholder Placeholder) (query string, args []any, err error) {
+	cols, err := columns(src)
+	if err != nil {
+		return
+	}
+	if len(cols) == 0 {
+		err = ErrNoColumns
+		return
+	}
+
+	names := make([]string, 0, len(cols))
+	values := make([]string, 0, len(cols))
+	for _, c := range cols {
+		names = append(names, c.name)
+		switch c.kind {
+		case columnAbsent:
+			values = append(values, "DEFAULT")
+		case columnNull:
+			values = append(values, "NULL")
+		case columnValue:
+			args = append(args, c.value)
+			values = append(values, placeholder(len(args)))
+		}
+	}
+
+	query = "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")"
+	return
+}
+
+// columns returns the columns of the struct src.
+func columns(src any) (cols []column, err error) {
+	rv := reflect.ValueOf(src)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: got %T", ErrNotStruct, src)
+		return
+	}
+
+	for _, f := range structs.Fields(rv.Type(), "db") {
+		c := column{name: columnName(f)}
+		c.kind, c.value, err = columnValueOf(rv.FieldByIndex(f.Index))
+		if err != nil {
+			err = fmt.Errorf("sqlbuild: column %s: %w", c.name, err)
+			return
+		}
+		cols = append(cols, c)
+	}
+	return
+}
+
+// columnName returns the column name of a field.
+func columnName(f structs.Field) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return strings.ToLower(f.StructField.Name)
+}
+
+// columnValueOf returns the kind and value of the column of a field.
+func columnValueOf(fv reflect.Value) (kind int, value driver.Value, err error) {
+	fv = structs.Addressable(fv)
+	if opt, ok := fv.Addr().Interface().(structs.Optional); ok && !opt.IsSome() {
+		kind = columnAbsent
+		return
+	}
+
+	// value
+	if fv.Kind() == reflect.Pointer && fv.IsNil() {
+		kind = columnNull
+		return
+	}
+	var v any = fv.Interface()
+	if valuer, ok := v.(driver.Valuer); ok {
+		v, err = valuer.Value()
+		if err != nil {
+			return
+		}
+	}
+	if v == nil {
+		kind = columnNull
+		return
+	}
+
+	kind, value = columnValue, v
+	return
+}
@@ -0,0 +1,150 @@
+package sqlbuild
+
+import (
+	"testing"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+type audit struct {
+	UpdatedBy optional.Option[string] `db:"updated_by"`
+}
+
+type user struct {
+	ID       int                                    `db:"-"`
+	Name     optional.Option[string]                `db:"name"`
+	Age      optional.Option[int]                   `db:"age"`
+	Nickname nullable.Null[string]                  `db:"nickname"`
+	Email    optional.Option[nullable.Null[string]] `db:"email"`
+	Active   bool
+	audit
+}
+
+// Tests for the placeholder styles
+func TestPlaceholder(t *testing.T) {
+	t.Run("question", func(t *testing.T) {
+		require.Equal(t, "?", Question(1))
+		require.Equal(t, "?", Question(2))
+	})
+
+	t.Run("dollar", func(t *testing.T) {
+		require.Equal(t, "$1", Dollar(1))
+		require.Equal(t, "$2", Dollar(2))
+	})
+
+	t.Run("at p", func(t *testing.T) {
+		require.Equal(t, "@p1", AtP(1))
+		require.Equal(t, "@p2", AtP(2))
+	})
+}
+
+// Tests for Update
+func TestUpdate(t *testing.T) {
+	t.Run("succeed to build - only some fields", func(t *testing.T) {
+		// arrange
+		u := user{
+			ID:       1,
+			Name:     optional.Some("Mary"),
+			Nickname: nullable.Some("mary"),
+			Active:   true,
+		}
+
+		// act
+		query, args, err := Update("users", u, Dollar)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE users SET name = $1, nickname = $2, active = $3", query)
+		require.Equal(t, []any{"Mary", "mary", true}, args)
+	})
+
+	t.Run("succeed to build - null values", func(t *testing.T) {
+		// arrange
+		u := user{
+			Age:      optional.Some(20),
+			Nickname: nullable.None[string](),
+			Email:    optional.Some(nullable.None[string]()),
+			Active:   false,
+			audit:    audit{UpdatedBy: optional.Some("admin")},
+		}
+
+		// act
+		query, args, err := Update("users", &u, Question)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE users SET age = ?, nickname = NULL, email = NULL, active = ?, updated_by = ?", query)
+		require.Equal(t, []any{int64(20), false, "admin"}, args)
+	})
+
+	t.Run("fail to build - no columns", func(t *testing.T) {
+		// arrange
+		type partial struct {
+			Name optional.Option[string] `db:"name"`
+		}
+
+		// act
+		_, _, err := Update("users", partial{}, Question)
+
+		// assert
+		require.ErrorIs(t, err, ErrNoColumns)
+	})
+
+	t.Run("fail to build - source is not a struct", func(t *testing.T) {
+		// arrange
+		// ...
+
+		// act
+		_, _, err := Update("users", 1, Question)
+
+		// assert
+		require.ErrorIs(t, err, ErrNotStruct)
+	})
+
+	t.Run("fail to build - invalid value", func(t *testing.T) {
+		// arrange
+		type invalid struct {
+			Tags optional.Option[[]string] `db:"tags"`
+		}
+
+		// act
+		_, _, err := Update("users", invalid{Tags: optional.Some([]string{"a"})}, Question)
+
+		// assert
+		require.Error(t, err)
+	})
+}
+
+// Tests for Insert
+func TestInsert(t *testing.T) {
+	t.Run("succeed to build - none fields use default", func(t *testing.T) {
+		// arrange
+		u := user{
+			Name:     optional.Some("Mary"),
+			Nickname: nullable.None[string](),
+			Email:    optional.Some(nullable.Some("mary@mail.com")),
+			Active:   true,
+		}
+
+		// act
+		query, args, err := Insert("users", u, AtP)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO users (name, age, nickname, email, active, updated_by) VALUES (@p1, DEFAULT, NULL, @p2, @p3, DEFAULT)", query)
+		require.Equal(t, []any{"Mary", "mary@mail.com", true}, args)
+	})
+
+	t.Run("fail to build - no columns", func(t *testing.T) {
+		// arrange
+		type empty struct{}
+
+		// act
+		_, _, err := Insert("users", empty{}, Question)
+
+		// assert
+		require.ErrorIs(t, err, ErrNoColumns)
+	})
+}
@@ -0,0 +1,157 @@
+package sqlbuild
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+var (
+	ErrUnknownOperator = errors.New("sqlbuild: unknown operator")
+)
+
+// operators maps the supported `optfilter` tag operators to their SQL operator.
+// The tag is shared with optional.BSONFilter, so a single criteria struct can be used for both:
+// the fields with the operators of BSONFilter only (regex, exists) are skipped.
+var operators = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+	"in":   "IN",
+	"nin":  "NOT IN",
+}
+
+// Offset returns a placeholder that starts numbering after the first n arguments.
+// It allows to chain statements, e.g. the WHERE clause of an UPDATE:
+//
+//	query, args, _ := Update("users", patch, Dollar)
+//	where, whereArgs, _ := Where(criteria, Dollar.Offset(len(args)))
+func (p Placeholder) Offset(n int) Placeholder {
+	return func(i int) string { return p(i + n) }
+}
+
+// Where builds a WHERE clause from a struct of criteria.
+//   - None criteria are skipped
+//   - Some criteria are compared with the operator of the `optfilter` tag (eq by default)
+//     > eq, ne, gt, gte, lt, lte, like, in, nin
+//     > slices (e.g. Option[[]T]) are expanded to IN (...) / NOT IN (...)
+//     > `optfilter:"-"` and the operators of optional.BSONFilter only (regex, exists) skip the field
+//   - NULL criteria (e.g. Some(nullable.None)) are compared with IS NULL / IS NOT NULL
+//   - any other field is always compared
+//
+// Conditions are joined with AND. If there are no conditions, the clause is empty.
+func Where(criteria any, placeholder Placeholder) (clause string, args []any, err error) {
+	rv := reflect.ValueOf(criteria)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: got %T", ErrNotStruct, criteria)
+		return
+	}
+
+	var conds []string
+	for _, f := range structs.Fields(rv.Type(), "db") {
+		name := columnName(f)
+
+		// operator
+		op, _, skip, known := structs.FilterOperator(f.StructField)
+		if !known {
+			err = fmt.Errorf("%w: %q on column %s", ErrUnknownOperator, op, name)
+			return
+		}
+		if _, ok := operators[op]; skip || !ok {
+			continue
+		}
+
+		// criteria
+		fv := structs.Addressable(rv.FieldByIndex(f.Index))
+		if opt, ok := fv.Addr().Interface().(structs.Optional); ok {
+			if !opt.IsSome() {
+				continue
+			}
+			fv = structs.Unwrap(fv)
+		}
+
+		var cond string
+		cond, args, err = condition(name, op, fv, args, placeholder)
+		if err != nil {
+			err = fmt.Errorf("sqlbuild: column %s: %w", name, err)
+			return
+		}
+		if cond != "" {
+			conds = append(conds, cond)
+		}
+	}
+
+	if len(conds) > 0 {
+		clause = "WHERE " + strings.Join(conds, " AND ")
+	}
+	return
+}
+
+// condition returns the condition of a column for the value v, appending its arguments to args.
+func condition(name string, op string, v reflect.Value, args []any, placeholder Placeholder) (cond string, _ []any, err error) {
+	// lists
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		switch op {
+		case "eq", "in":
+			op = "in"
+		case "ne", "nin":
+			op = "nin"
+		default:
+			err = fmt.Errorf("%w: %q with a list", ErrUnknownOperator, op)
+			return
+		}
+
+		// empty lists: nothing is in it
+		if v.Len() == 0 {
+			if op == "in" {
+				cond = "1 = 0"
+			}
+			return cond, args, nil
+		}
+
+		phs := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			args = append(args, v.Index(i).Interface())
+			phs[i] = placeholder(len(args))
+		}
+		cond = name + " " + operators[op] + " (" + strings.Join(phs, ", ") + ")"
+		return cond, args, nil
+	}
+
+	// values
+	kind, value, err := columnValueOf(v)
+	if err != nil {
+		return
+	}
+	if kind == columnNull {
+		switch op {
+		case "eq", "in":
+			cond = name + " IS NULL"
+		case "ne", "nin":
+			cond = name + " IS NOT NULL"
+		default:
+			err = fmt.Errorf("%w: %q with NULL", ErrUnknownOperator, op)
+		}
+		return cond, args, err
+	}
+
+	switch op {
+	case "in":
+		op = "eq"
+	case "nin":
+		op = "ne"
+	}
+	args = append(args, value)
+	cond = name + " " + operators[op] + " " + placeholder(len(args))
+	return cond, args, nil
+}
@@ -0,0 +1,203 @@
+package sqlbuild
+
+import (
+	"testing"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type userCriteria struct {
+	Name     optional.Option[string]                `db:"name"`
+	Email    optional.Option[string]                `db:"email" optfilter:"like"`
+	MinAge   optional.Option[int]                   `db:"age" optfilter:"gte"`
+	MaxAge   optional.Option[int]                   `db:"age" optfilter:"lt"`
+	IDs      optional.Option[[]int]                 `db:"id"`
+	Excluded optional.Option[[]string]              `db:"country" optfilter:"nin"`
+	Deleted  optional.Option[nullable.Null[string]] `db:"deleted_at"`
+	Nickname optional.Option[nullable.Null[string]] `db:"nickname" optfilter:"ne"`
+}
+
+// Tests for Offset
+func TestPlaceholder_Offset(t *testing.T) {
+	t.Run("dollar", func(t *testing.T) {
+		require.Equal(t, "$3", Dollar.Offset(2)(1))
+	})
+
+	t.Run("question", func(t *testing.T) {
+		require.Equal(t, "?", Question.Offset(2)(1))
+	})
+}
+
+// Tests for Where
+func TestWhere(t *testing.T) {
+	t.Run("succeed to build - all criteria none", func(t *testing.T) {
+		// arrange
+		c := userCriteria{}
+
+		// act
+		clause, args, err := Where(c, Question)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "", clause)
+		require.Nil(t, args)
+	})
+
+	t.Run("succeed to build - operators", func(t *testing.T) {
+		// arrange
+		c := userCriteria{
+			Name:   optional.Some("Mary"),
+			Email:  optional.Some("%@mail.com"),
+			MinAge: optional.Some(18),
+			MaxAge: optional.Some(65),
+		}
+
+		// act
+		clause, args, err := Where(&c, Dollar)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "WHERE name = $1 AND email LIKE $2 AND age >= $3 AND age < $4", clause)
+		require.Equal(t, []any{"Mary", "%@mail.com", 18, 65}, args)
+	})
+
+	t.Run("succeed to build - lists are expanded", func(t *testing.T) {
+		// arrange
+		c := userCriteria{
+			IDs:      optional.Some([]int{1, 2, 3}),
+			Excluded: optional.Some([]string{"AR"}),
+		}
+
+		// act
+		clause, args, err := Where(c, AtP)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "WHERE id IN (@p1, @p2, @p3) AND country NOT IN (@p4)", clause)
+		require.Equal(t, []any{1, 2, 3, "AR"}, args)
+	})
+
+	t.Run("succeed to build - empty lists", func(t *testing.T) {
+		// arrange
+		c := userCriteria{
+			IDs:      optional.Some([]int{}),
+			Excluded: optional.Some([]string{}),
+		}
+
+		// act
+		clause, args, err := Where(c, Question)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "WHERE 1 = 0", clause)
+		require.Nil(t, args)
+	})
+
+	t.Run("succeed to build - null criteria", func(t *testing.T) {
+		// arrange
+		c := userCriteria{
+			Deleted:  optional.Some(nullable.None[string]()),
+			Nickname: optional.Some(nullable.None[string]()),
+		}
+
+		// act
+		clause, args, err := Where(c, Question)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "WHERE deleted_at IS NULL AND nickname IS NOT NULL", clause)
+		require.Nil(t, args)
+	})
+
+	t.Run("succeed to build - chained with an update", func(t *testing.T) {
+		// arrange
+		patch := user{Name: optional.Some("Mary"), Nickname: nullable.Some("mary"), Active: true}
+		c := userCriteria{IDs: optional.Some([]int{1})}
+
+		// act
+		query, args, err := Update("users", patch, Dollar)
+		require.NoError(t, err)
+		clause, whereArgs, err := Where(c, Dollar.Offset(len(args)))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE users SET name = $1, nickname = $2, active = $3 WHERE id IN ($4)", query+" "+clause)
+		require.Equal(t, []any{"Mary", "mary", true, 1}, append(args, whereArgs...))
+	})
+
+	t.Run("succeed to build - criteria shared with BSONFilter", func(t *testing.T) {
+		// arrange
+		type Ages struct {
+			MinAge optional.Option[int] `db:"age" bson:"age" optfilter:"gte"`
+		}
+		type criteria struct {
+			Name  optional.Option[string] `db:"name" bson:"name"`
+			Email optional.Option[string] `db:"email" bson:"email" optfilter:"like"`
+			Title optional.Option[string] `db:"title" bson:"title" optfilter:"regex,i"`
+			Ages
+			Secret optional.Option[string] `db:"secret" bson:"secret" optfilter:"-"`
+		}
+		c := criteria{
+			Name:   optional.Some("Mary"),
+			Email:  optional.Some("%@mail.com"),
+			Title:  optional.Some("^dev"),
+			Ages:   Ages{MinAge: optional.Some(18)},
+			Secret: optional.Some("x"),
+		}
+
+		// act
+		clause, args, err := Where(c, Question)
+		filter, bsonErr := optional.BSONFilter(c)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "WHERE name = ? AND email LIKE ? AND age >= ?", clause)
+		require.Equal(t, []any{"Mary", "%@mail.com", 18}, args)
+		require.NoError(t, bsonErr)
+		require.Equal(t, bson.D{
+			{Key: "name", Value: "Mary"},
+			{Key: "title", Value: bson.D{{Key: "$regex", Value: "^dev"}, {Key: "$options", Value: "i"}}},
+			{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}}},
+		}, filter)
+	})
+
+	t.Run("fail to build - unknown operator", func(t *testing.T) {
+		// arrange
+		type invalid struct {
+			Age optional.Option[int] `db:"age" optfilter:"between"`
+		}
+
+		// act
+		_, _, err := Where(invalid{}, Question)
+
+		// assert
+		require.ErrorIs(t, err, ErrUnknownOperator)
+	})
+
+	t.Run("fail to build - list with a comparison operator", func(t *testing.T) {
+		// arrange
+		type invalid struct {
+			Ages optional.Option[[]int] `db:"age" optfilter:"gt"`
+		}
+
+		// act
+		_, _, err := Where(invalid{Ages: optional.Some([]int{1})}, Question)
+
+		// assert
+		require.ErrorIs(t, err, ErrUnknownOperator)
+	})
+
+	t.Run("fail to build - criteria is not a struct", func(t *testing.T) {
+		// arrange
+		// ...
+
+		// act
+		_, _, err := Where("name", Question)
+
+		// assert
+		require.ErrorIs(t, err, ErrNotStruct)
+	})
+}
@@ -0,0 +1,145 @@
+// Package sqlscan scans database rows into structs with optional.Option and
+// nullable.Null fields.
+//
+// Columns are mapped to fields with the `db` tag (or the lowercased field name), `db:"-"` skips a field.
+// - NULL columns are scanned as None (Option) or Null (Null)
+// - any other column is converted following the database/sql rules
+package sqlscan
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/LNMMusic/optional/internal/sqlconv"
+	"github.com/LNMMusic/optional/internal/structs"
+)
+
+var (
+	ErrInvalidDestination = errors.New("sqlscan: invalid destination")
+	ErrUnmappedColumn     = errors.New("sqlscan: column is not mapped to any field")
+)
+
+// ColumnError is the error of a column that could not be scanned.
+type ColumnError struct {
+	// Column is the name of the column.
+	Column string
+	// Field is the name of the struct field, empty if the column is not mapped.
+	Field string
+	// Err is the cause of the error.
+	Err error
+}
+
+// Error returns the message of the error.
+func (e *ColumnError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("sqlscan: column %s: %v", e.Column, e.Err)
+	}
+	return fmt.Sprintf("sqlscan: column %s into field %s: %v", e.Column, e.Field, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+// ScanStruct scans the current row into the struct pointed by dst.
+// It must be called after rows.Next, as rows.Scan.
+//
+// All the columns are scanned before returning, so the returned error joins
+// a *ColumnError for each unmapped or mismatched column.
+func ScanStruct(rows *sql.Rows, dst any) (err error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: expected a pointer to a struct, got %T", ErrInvalidDestination, dst)
+		return
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	err = scanRow(rows, columns, fieldsOf(rv.Elem().Type()), rv.Elem())
+	return
+}
+
+// ScanAll scans all the rows into the slice pointed by dst and closes them.
+// The elements of the slice can be structs or pointers to structs.
+func ScanAll(rows *sql.Rows, dst any) (err error) {
+	defer rows.Close()
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		err = fmt.Errorf("%w: expected a pointer to a slice, got %T", ErrInvalidDestination, dst)
+		return
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		err = fmt.Errorf("%w: expected a slice of structs, got %T", ErrInvalidDestination, dst)
+		return
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return
+	}
+	fields := fieldsOf(structType)
+	for rows.Next() {
+		elem := reflect.New(structType)
+		if err = scanRow(rows, columns, fields, elem.Elem()); err != nil {
+			return
+		}
+		if elemType.Kind() == reflect.Pointer {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+	err = rows.Err()
+	return
+}
+
+// fieldsOf returns the fields of a struct type by column name.
+func fieldsOf(t reflect.Type) map[string]structs.Field {
+	fields := make(map[string]structs.Field)
+	for _, f := range structs.Fields(t, "db") {
+		name := f.Name
+		if name == "" {
+			name = strings.ToLower(f.StructField.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// scanRow scans the current row into the struct value sv.
+func scanRow(rows *sql.Rows, columns []string, fields map[string]structs.Field, sv reflect.Value) error {
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, column := range columns {
+		f, ok := fields[column]
+		if !ok {
+			errs = append(errs, &ColumnError{Column: column, Err: ErrUnmappedColumn})
+			continue
+		}
+		if err := sqlconv.Assign(sv.FieldByIndex(f.Index), values[i]); err != nil {
+			errs = append(errs, &ColumnError{Column: column, Field: f.StructField.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,254 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/LNMMusic/optional"
+	"github.com/LNMMusic/optional/nullable"
+	"github.com/stretchr/testify/require"
+)
+
+// fake driver: the query is the name of a fixture, each fixture is a set of columns and rows
+type fixture struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var fixtures = map[string]fixture{
+	"users": {
+		columns: []string{"id", "name", "age", "nickname", "created_at"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Mary"), int64(20), "mary", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{int64(2), "John", nil, nil, nil},
+		},
+	},
+	"unmapped": {
+		columns: []string{"id", "unknown"},
+		rows:    [][]driver.Value{{int64(1), "value"}},
+	},
+	"mismatched": {
+		columns: []string{"id", "name", "age"},
+		rows:    [][]driver.Value{{nil, "Mary", "twenty"}},
+	},
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeStmt struct{ query string }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return 0 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	f, ok := fixtures[s.query]
+	if !ok {
+		return nil, errors.New("unknown fixture")
+	}
+	return &fakeRows{fixture: f}, nil
+}
+
+type fakeRows struct {
+	fixture fixture
+	index   int
+}
+
+func (r *fakeRows) Columns() []string { return r.fixture.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.fixture.rows) {
+		return io.EOF
+	}
+	copy(dest, r.fixture.rows[r.index])
+	r.index++
+	return nil
+}
+
+func init() {
+	sql.Register("sqlscan-fake", fakeDriver{})
+}
+
+func query(t *testing.T, fixture string) *sql.Rows {
+	db, err := sql.Open("sqlscan-fake", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query(fixture)
+	require.NoError(t, err)
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+type user struct {
+	ID        int                        `db:"id"`
+	Name      optional.Option[string]    `db:"name"`
+	Age       optional.Option[int]       `db:"age"`
+	Nickname  nullable.Null[string]      `db:"nickname"`
+	CreatedAt optional.Option[time.Time] `db:"created_at"`
+	Ignored   string                     `db:"-"`
+}
+
+// Tests for ScanStruct
+func TestScanStruct(t *testing.T) {
+	t.Run("succeed to scan - values", func(t *testing.T) {
+		// arrange
+		rows := query(t, "users")
+		require.True(t, rows.Next())
+
+		// act
+		var u user
+		err := ScanStruct(rows, &u)
+
+		// assert
+		expectedUser := user{
+			ID:        1,
+			Name:      optional.Some("Mary"),
+			Age:       optional.Some(20),
+			Nickname:  nullable.Some("mary"),
+			CreatedAt: optional.Some(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedUser, u)
+	})
+
+	t.Run("succeed to scan - nulls into a reused struct", func(t *testing.T) {
+		// arrange
+		rows := query(t, "users")
+		require.True(t, rows.Next())
+		require.True(t, rows.Next())
+
+		// act
+		u := user{Age: optional.Some(30), Nickname: nullable.Some("old"), CreatedAt: optional.Some(time.Now())}
+		err := ScanStruct(rows, &u)
+
+		// assert
+		expectedUser := user{
+			ID:        2,
+			Name:      optional.Some("John"),
+			Age:       optional.None[int](),
+			Nickname:  nullable.None[string](),
+			CreatedAt: optional.None[time.Time](),
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedUser, u)
+	})
+
+	t.Run("fail to scan - unmapped column", func(t *testing.T) {
+		// arrange
+		rows := query(t, "unmapped")
+		require.True(t, rows.Next())
+
+		// act
+		var u user
+		err := ScanStruct(rows, &u)
+
+		// assert
+		require.ErrorIs(t, err, ErrUnmappedColumn)
+		var columnErr *ColumnError
+		require.ErrorAs(t, err, &columnErr)
+		require.Equal(t, "unknown", columnErr.Column)
+		require.Equal(t, 1, u.ID)
+	})
+
+	t.Run("fail to scan - mismatched columns are all reported", func(t *testing.T) {
+		// arrange
+		rows := query(t, "mismatched")
+		require.True(t, rows.Next())
+
+		// act
+		var u user
+		err := ScanStruct(rows, &u)
+
+		// assert
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "column id into field ID")
+		require.Contains(t, err.Error(), "column age into field Age")
+		require.Equal(t, optional.Some("Mary"), u.Name)
+	})
+
+	t.Run("fail to scan - destination is not a pointer to a struct", func(t *testing.T) {
+		// arrange
+		rows := query(t, "users")
+		require.True(t, rows.Next())
+
+		// act
+		var u user
+		err := ScanStruct(rows, u)
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidDestination)
+	})
+}
+
+// Tests for ScanAll
+func TestScanAll(t *testing.T) {
+	t.Run("succeed to scan - slice of structs", func(t *testing.T) {
+		// arrange
+		rows := query(t, "users")
+
+		// act
+		var users []user
+		err := ScanAll(rows, &users)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, optional.Some("Mary"), users[0].Name)
+		require.Equal(t, optional.None[int](), users[1].Age)
+		require.Equal(t, nullable.None[string](), users[1].Nickname)
+	})
+
+	t.Run("succeed to scan - slice of pointers", func(t *testing.T) {
+		// arrange
+		rows := query(t, "users")
+
+		// act
+		var users []*user
+		err := ScanAll(rows, &users)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, 2, users[1].ID)
+	})
+
+	t.Run("fail to scan - mismatched column", func(t *testing.T) {
+		// arrange
+		rows := query(t, "mismatched")
+
+		// act
+		var users []user
+		err := ScanAll(rows, &users)
+
+		// assert
+		var columnErr *ColumnError
+		require.ErrorAs(t, err, &columnErr)
+		require.Empty(t, users)
+	})
+
+	t.Run("fail to scan - destination is not a slice of structs", func(t *testing.T) {
+		// arrange
+		rows := query(t, "users")
+
+		// act
+		var ids []int
+		err := ScanAll(rows, &ids)
+
+		// assert
+		require.ErrorIs(t, err, ErrInvalidDestination)
+	})
+}
@@ -0,0 +1,61 @@
+package optional
+
+import (
+	"errors"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var (
+	ErrNullNotAllowed = errors.New("optional: null is not allowed, the key must be omitted instead")
+)
+
+// StrictOption is an Option that may only be absent: an explicit null is rejected on decoding
+// instead of being treated as None (as Option does).
+// It is meant for APIs where `{"name": null}` must be a client error, not an absent key.
+//
+// - unmarshal json: null -> *DecodeError wrapping ErrNullNotAllowed, any other value as Option
+// - unmarshal bson: null and undefined -> *DecodeError wrapping ErrNullNotAllowed, any other value as Option
+// - marshal: as Option (None -> null)
+//
+// The methods of the embedded Option (IsSome, Unwrap, ...) are promoted.
+type StrictOption[T any] struct {
+	Option[T]
+}
+
+// SomeStrict returns a StrictOption with a Some value.
+func SomeStrict[T any](value T) StrictOption[T] {
+	return StrictOption[T]{Option: Some(value)}
+}
+
+// NoneStrict returns a StrictOption with a None value.
+func NoneStrict[T any]() StrictOption[T] {
+	return StrictOption[T]{Option: None[T]()}
+}
+
+// UnmarshalJSON indicates how to unmarshal a json value into a StrictOption.
+// Errors are returned as *DecodeError.
+func (o *StrictOption[T]) UnmarshalJSON(data []byte) (err error) {
+	if string(data) == "null" {
+		return decodeerr.JSON(o.innerType(), data, ErrNullNotAllowed)
+	}
+	return o.Option.UnmarshalJSON(data)
+}
+
+// UnmarshalBSONValue indicates how to unmarshal a bson value into a StrictOption.
+// Errors are returned as *DecodeError.
+func (o *StrictOption[T]) UnmarshalBSONValue(t bsontype.Type, data []byte) (err error) {
+	if t == bsontype.Null || t == bsontype.Undefined {
+		return decodeerr.BSON(o.innerType(), t, data, ErrNullNotAllowed)
+	}
+	return o.Option.UnmarshalBSONValue(t, data)
+}
+
+// rejectsNull marks StrictOption for the reflection based decoders (e.g. the bson codecs).
+func (o *StrictOption[T]) rejectsNull() {}
+
+// nullRejecter is implemented by the Options that reject null values (StrictOption).
+type nullRejecter interface {
+	rejectsNull()
+}
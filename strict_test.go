@@ -0,0 +1,130 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type strictSchema struct {
+	Name StrictOption[string] `json:"name" bson:"name,omitempty"`
+	Age  StrictOption[int]    `json:"age" bson:"age,omitempty"`
+}
+
+// Tests for StrictOption UnmarshalJSON
+func TestStrictOption_UnmarshalJSON(t *testing.T) {
+	t.Run("JSON - values and absent keys", func(t *testing.T) {
+		// act
+		var s strictSchema
+		err := json.Unmarshal([]byte(`{"name": "Mary"}`), &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "Mary", s.Name.Unwrap())
+		require.False(t, s.Age.IsSome())
+	})
+
+	t.Run("JSON - null is rejected", func(t *testing.T) {
+		// act
+		var s strictSchema
+		err := json.Unmarshal([]byte(`{"name": null}`), &s)
+
+		// assert
+		require.ErrorIs(t, err, ErrNullNotAllowed)
+		require.EqualError(t, err, "optional: cannot decode json null into string: optional: null is not allowed, the key must be omitted instead")
+	})
+
+	t.Run("JSON - invalid value", func(t *testing.T) {
+		// act
+		var s strictSchema
+		err := json.Unmarshal([]byte(`{"age": "x"}`), &s)
+
+		// assert
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrNullNotAllowed)
+	})
+
+	t.Run("JSON - lenient decoding reports null", func(t *testing.T) {
+		// act
+		var s strictSchema
+		report, err := UnmarshalJSONLenient([]byte(`{"name": null, "age": 1}`), &s)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, s.Name.IsSome())
+		require.Equal(t, 1, s.Age.Unwrap())
+		require.Len(t, report, 1)
+		require.Equal(t, "name", report[0].Path)
+		require.ErrorIs(t, report[0], ErrNullNotAllowed)
+	})
+}
+
+// Tests for StrictOption MarshalJSON
+func TestStrictOption_MarshalJSON(t *testing.T) {
+	// act
+	data, err := json.Marshal(strictSchema{Name: SomeStrict("Mary"), Age: NoneStrict[int]()})
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Mary","age":null}`, string(data))
+}
+
+// Tests for StrictOption UnmarshalBSONValue
+func TestStrictOption_UnmarshalBSONValue(t *testing.T) {
+	t.Run("BSON - values and absent keys", func(t *testing.T) {
+		// arrange
+		data, err := bson.Marshal(bson.D{{Key: "name", Value: "Mary"}})
+		require.NoError(t, err)
+
+		// act
+		var s strictSchema
+		err = bson.Unmarshal(data, &s)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "Mary", s.Name.Unwrap())
+		require.False(t, s.Age.IsSome())
+	})
+
+	t.Run("BSON - null and undefined are rejected", func(t *testing.T) {
+		for _, value := range []any{nil, primitive.Undefined{}} {
+			// arrange
+			data, err := bson.Marshal(bson.D{{Key: "name", Value: value}})
+			require.NoError(t, err)
+
+			// act
+			var s strictSchema
+			err = bson.Unmarshal(data, &s)
+
+			// assert
+			require.ErrorIs(t, err, ErrNullNotAllowed)
+		}
+	})
+
+	t.Run("BSON - null is rejected by the registered codecs", func(t *testing.T) {
+		// arrange
+		registry := RegisterBSONCodecs(bson.NewRegistryBuilder()).Build()
+		data, err := bson.Marshal(bson.D{{Key: "age", Value: nil}})
+		require.NoError(t, err)
+
+		// act
+		var s strictSchema
+		err = bson.UnmarshalWithRegistry(registry, data, &s)
+
+		// assert
+		require.ErrorIs(t, err, ErrNullNotAllowed)
+	})
+
+	t.Run("BSON - direct call", func(t *testing.T) {
+		// act
+		var option StrictOption[int]
+		err := option.UnmarshalBSONValue(bsontype.Undefined, nil)
+
+		// assert
+		require.ErrorIs(t, err, ErrNullNotAllowed)
+	})
+}
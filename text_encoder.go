@@ -0,0 +1,54 @@
+package optional
+
+import (
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/textconv"
+)
+
+// UnmarshalText indicates how to unmarshal a text into an Option.
+// The inner value is decoded with its encoding.TextUnmarshaler or strconv for the builtin kinds
+// (errors as *DecodeError). The empty string is decoded as None.
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	return o.UnmarshalTextWithNone(text, "")
+}
+
+// UnmarshalTextWithNone unmarshals a text into an Option as UnmarshalText,
+// but the text none (e.g. "-") is also decoded as None.
+func (o *Option[T]) UnmarshalTextWithNone(text []byte, none string) (err error) {
+	if len(text) == 0 || string(text) == none {
+		o.value = nil
+		return
+	}
+
+	var v T
+	if err = textconv.Unmarshal(string(text), &v); err != nil {
+		err = decodeerr.New("text", o.innerType(), string(text), err)
+		return
+	}
+	o.value = &v
+	return
+}
+
+// MarshalText indicates how to marshal an Option into a text.
+// The inner value is encoded with its encoding.TextMarshaler or strconv for the builtin kinds.
+// None is encoded as the empty string.
+//
+// Option holds a pointer, so it is not supported as a map key: two Options with the same value
+// are different keys. nullable.Null can be used instead.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	return o.MarshalTextWithNone("")
+}
+
+// MarshalTextWithNone marshals an Option into a text as MarshalText, but None is encoded as the text none.
+func (o Option[T]) MarshalTextWithNone(none string) (text []byte, err error) {
+	if o.value == nil {
+		return []byte(none), nil
+	}
+
+	s, err := textconv.Marshal(o.value)
+	if err != nil {
+		return
+	}
+	text = []byte(s)
+	return
+}
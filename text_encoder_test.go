@@ -0,0 +1,173 @@
+package optional
+
+import (
+	"flag"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Option UnmarshalText
+func TestOption_UnmarshalText(t *testing.T) {
+	t.Run("Text - builtin kinds", func(t *testing.T) {
+		var s Option[string]
+		require.NoError(t, s.UnmarshalText([]byte("hello")))
+		require.Equal(t, "hello", s.Unwrap())
+
+		var i Option[int8]
+		require.NoError(t, i.UnmarshalText([]byte("-12")))
+		require.Equal(t, int8(-12), i.Unwrap())
+
+		var u Option[uint]
+		require.NoError(t, u.UnmarshalText([]byte("12")))
+		require.Equal(t, uint(12), u.Unwrap())
+
+		var f Option[float32]
+		require.NoError(t, f.UnmarshalText([]byte("1.5")))
+		require.Equal(t, float32(1.5), f.Unwrap())
+
+		var b Option[bool]
+		require.NoError(t, b.UnmarshalText([]byte("true")))
+		require.Equal(t, true, b.Unwrap())
+
+		var d Option[time.Duration]
+		require.NoError(t, d.UnmarshalText([]byte("1m30s")))
+		require.Equal(t, 90*time.Second, d.Unwrap())
+	})
+
+	t.Run("Text - inner text unmarshaler", func(t *testing.T) {
+		// act
+		var addr Option[netip.Addr]
+		err := addr.UnmarshalText([]byte("10.0.0.1"))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, netip.MustParseAddr("10.0.0.1"), addr.Unwrap())
+	})
+
+	t.Run("Text - empty is none", func(t *testing.T) {
+		// act
+		option := Some(1)
+		err := option.UnmarshalText(nil)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, option.IsSome())
+	})
+
+	t.Run("Text - none text", func(t *testing.T) {
+		// act
+		option, empty := Some(1), Some(1)
+		err1 := option.UnmarshalTextWithNone([]byte("-"), "-")
+		err2 := empty.UnmarshalTextWithNone(nil, "-")
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.False(t, option.IsSome())
+		require.False(t, empty.IsSome())
+	})
+
+	// errors
+	type errorCase struct {
+		title string
+		text  string
+		err   func(text string) error
+	}
+	errorCases := []errorCase{
+		{title: "Text - invalid int", text: "12a", err: func(text string) error { var o Option[int]; return o.UnmarshalText([]byte(text)) }},
+		{title: "Text - int out of range", text: "300", err: func(text string) error { var o Option[uint8]; return o.UnmarshalText([]byte(text)) }},
+		{title: "Text - invalid bool", text: "yes", err: func(text string) error { var o Option[bool]; return o.UnmarshalText([]byte(text)) }},
+		{title: "Text - invalid duration", text: "5", err: func(text string) error { var o Option[time.Duration]; return o.UnmarshalText([]byte(text)) }},
+		{title: "Text - unsupported type", text: "x", err: func(text string) error { var o Option[[]int]; return o.UnmarshalText([]byte(text)) }},
+	}
+	for _, c := range errorCases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			err := c.err(c.text)
+
+			// assert
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			require.Equal(t, "text", decodeErr.Format)
+			require.Equal(t, c.text, decodeErr.Raw)
+		})
+	}
+}
+
+// Tests for Option MarshalText
+func TestOption_MarshalText(t *testing.T) {
+	type input struct {
+		value interface{ MarshalText() ([]byte, error) }
+	}
+	type output struct{ text string }
+	type testCase struct {
+		title  string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		{title: "Text - string", input: input{value: Some("hello")}, output: output{text: "hello"}},
+		{title: "Text - int", input: input{value: Some(-1)}, output: output{text: "-1"}},
+		{title: "Text - uint64", input: input{value: Some(uint64(18446744073709551615))}, output: output{text: "18446744073709551615"}},
+		{title: "Text - float64", input: input{value: Some(0.1)}, output: output{text: "0.1"}},
+		{title: "Text - bool", input: input{value: Some(false)}, output: output{text: "false"}},
+		{title: "Text - duration", input: input{value: Some(90 * time.Second)}, output: output{text: "1m30s"}},
+		{title: "Text - text marshaler", input: input{value: Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))}, output: output{text: "2024-01-02T03:04:05Z"}},
+		{title: "Text - none", input: input{value: None[int]()}, output: output{text: ""}},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			// act
+			text, err := c.input.value.MarshalText()
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, c.output.text, string(text))
+		})
+	}
+
+	t.Run("Text - none text", func(t *testing.T) {
+		// act
+		none, err1 := None[int]().MarshalTextWithNone("null")
+		some, err2 := Some(1).MarshalTextWithNone("null")
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.Equal(t, "null", string(none))
+		require.Equal(t, "1", string(some))
+	})
+}
+
+// Tests for the uses of the Option text encoding
+func TestOption_TextUses(t *testing.T) {
+	t.Run("Map - Options are not supported as keys", func(t *testing.T) {
+		// act
+		counts := map[Option[int]]int{Some(1): 10, Some(1): 20}
+
+		// assert
+		require.Len(t, counts, 2)
+	})
+
+	t.Run("Flag - text var", func(t *testing.T) {
+		// arrange
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		var port, timeout Option[int]
+		fs.TextVar(&port, "port", None[int](), "port")
+		fs.TextVar(&timeout, "timeout", None[int](), "timeout")
+
+		// act
+		err := fs.Parse([]string{"-port", "8080"})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 8080, port.Unwrap())
+		require.False(t, timeout.IsSome())
+	})
+}
@@ -0,0 +1,69 @@
+package optional
+
+import (
+	"encoding/xml"
+
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"github.com/LNMMusic/optional/internal/textconv"
+	"github.com/LNMMusic/optional/internal/xsi"
+)
+
+// UnmarshalXML indicates how to unmarshal a xml element into an Option.
+// - an element with xsi:nil="true" is decoded as None
+// - any other element is decoded as Some (errors as *DecodeError)
+func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	if xsi.IsNil(start) {
+		o.value = nil
+		return d.Skip()
+	}
+
+	var v T
+	if err = d.DecodeElement(&v, &start); err != nil {
+		err = decodeerr.New("xml", o.innerType(), "", err)
+		return
+	}
+	o.value = &v
+	return
+}
+
+// MarshalXML indicates how to marshal an Option into a xml element.
+// None elements are omitted.
+func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	if o.value == nil {
+		return
+	}
+	return e.EncodeElement(*o.value, start)
+}
+
+// UnmarshalXMLAttr indicates how to unmarshal a xml attribute into an Option.
+// The inner value is decoded with its xml.UnmarshalerAttr, its encoding.TextUnmarshaler or strconv.
+func (o *Option[T]) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	var v T
+	if u, ok := any(&v).(xml.UnmarshalerAttr); ok {
+		err = u.UnmarshalXMLAttr(attr)
+	} else {
+		err = textconv.Unmarshal(attr.Value, &v)
+	}
+	if err != nil {
+		err = decodeerr.New("xml", o.innerType(), attr.Value, err)
+		return
+	}
+	o.value = &v
+	return
+}
+
+// MarshalXMLAttr indicates how to marshal an Option into a xml attribute.
+// None attributes are omitted. The inner value is encoded with its xml.MarshalerAttr,
+// its encoding.TextMarshaler or strconv.
+func (o Option[T]) MarshalXMLAttr(name xml.Name) (attr xml.Attr, err error) {
+	if o.value == nil {
+		return
+	}
+	if m, ok := any(o.value).(xml.MarshalerAttr); ok {
+		return m.MarshalXMLAttr(name)
+	}
+
+	attr.Name = name
+	attr.Value, err = textconv.Marshal(o.value)
+	return
+}
@@ -0,0 +1,157 @@
+package optional
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type xmlAddress struct {
+	Kind   Option[string] `xml:"kind,attr"`
+	Street Option[string] `xml:"street"`
+	Number Option[int]    `xml:"number"`
+}
+
+type xmlOrder struct {
+	XMLName  xml.Name           `xml:"order"`
+	ID       Option[int64]      `xml:"id,attr"`
+	Express  Option[bool]       `xml:"express,attr"`
+	Date     Option[time.Time]  `xml:"date,attr"`
+	Customer Option[string]     `xml:"customer"`
+	Address  Option[xmlAddress] `xml:"address"`
+	Items    []Option[int]      `xml:"items>item"`
+	Notes    []xmlAddress       `xml:"note"`
+}
+
+// Tests for Option UnmarshalXML and UnmarshalXMLAttr
+func TestOption_UnmarshalXML(t *testing.T) {
+	t.Run("XML - elements and attributes", func(t *testing.T) {
+		// arrange
+		data := []byte(`
+<order id="42" express="true" date="2024-01-02T03:04:05Z">
+	<customer>Mary</customer>
+	<address kind="home"><street>Main</street><number>7</number></address>
+	<items><item>1</item><item>2</item></items>
+	<note><street>Side</street></note>
+	<note kind="work"></note>
+</order>`)
+
+		// act
+		var order xmlOrder
+		err := xml.Unmarshal(data, &order)
+
+		// assert
+		require.NoError(t, err)
+		expectedOrder := xmlOrder{
+			XMLName:  xml.Name{Local: "order"},
+			ID:       Some(int64(42)),
+			Express:  Some(true),
+			Date:     Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Customer: Some("Mary"),
+			Address:  Some(xmlAddress{Kind: Some("home"), Street: Some("Main"), Number: Some(7)}),
+			Items:    []Option[int]{Some(1), Some(2)},
+			Notes:    []xmlAddress{{Street: Some("Side")}, {Kind: Some("work")}},
+		}
+		require.Equal(t, expectedOrder, order)
+	})
+
+	t.Run("XML - absent and nil elements are none", func(t *testing.T) {
+		// arrange
+		data := []byte(`
+<order xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+	<customer xsi:nil="true"/>
+	<address xsi:nil="true"><street>ignored</street></address>
+	<items><item>1</item><item xsi:nil="true"/></items>
+</order>`)
+
+		// act
+		var order xmlOrder
+		err := xml.Unmarshal(data, &order)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, order.ID.IsSome())
+		require.False(t, order.Customer.IsSome())
+		require.False(t, order.Address.IsSome())
+		require.Equal(t, []Option[int]{Some(1), None[int]()}, order.Items)
+	})
+
+	t.Run("XML - undeclared xsi prefix", func(t *testing.T) {
+		// act
+		var order xmlOrder
+		err := xml.Unmarshal([]byte(`<order><customer xsi:nil="true"/></order>`), &order)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, order.Customer.IsSome())
+	})
+
+	t.Run("XML - invalid element", func(t *testing.T) {
+		// act
+		var order xmlOrder
+		err := xml.Unmarshal([]byte(`<order><address><number>seven</number></address></order>`), &order)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "xml", decodeErr.Format)
+		require.Equal(t, "int", decodeErr.Type.String())
+	})
+
+	t.Run("XML - invalid attribute", func(t *testing.T) {
+		// act
+		var order xmlOrder
+		err := xml.Unmarshal([]byte(`<order id="x"></order>`), &order)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "x", decodeErr.Raw)
+	})
+}
+
+// Tests for Option MarshalXML and MarshalXMLAttr
+func TestOption_MarshalXML(t *testing.T) {
+	t.Run("XML - none elements and attributes are omitted", func(t *testing.T) {
+		// arrange
+		order := xmlOrder{
+			ID:      Some(int64(42)),
+			Address: Some(xmlAddress{Street: Some("Main")}),
+			Items:   []Option[int]{Some(1), None[int](), Some(3)},
+		}
+
+		// act
+		data, err := xml.Marshal(order)
+
+		// assert
+		require.NoError(t, err)
+		expectedData := `<order id="42"><address><street>Main</street></address><items><item>1</item><item>3</item></items></order>`
+		require.Equal(t, expectedData, string(data))
+	})
+
+	t.Run("XML - round trip", func(t *testing.T) {
+		// arrange
+		order := xmlOrder{
+			XMLName:  xml.Name{Local: "order"},
+			ID:       Some(int64(-1)),
+			Express:  Some(false),
+			Date:     Some(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)),
+			Customer: Some("<Mary & co>"),
+			Address:  Some(xmlAddress{Kind: Some("home"), Number: Some(0)}),
+			Items:    []Option[int]{Some(1)},
+			Notes:    []xmlAddress{{Street: Some("a")}, {Number: Some(2)}},
+		}
+
+		// act
+		data, err := xml.Marshal(order)
+		require.NoError(t, err)
+		var decoded xmlOrder
+		err = xml.Unmarshal(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, order, decoded)
+	})
+}
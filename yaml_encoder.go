@@ -0,0 +1,46 @@
+package optional
+
+import (
+	"github.com/LNMMusic/optional/internal/decodeerr"
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML indicates how to unmarshal a yaml node into an Option.
+// - null (`~`, `null` or an empty value) is decoded as None
+// - any other value is decoded as Some (errors as *DecodeError)
+//
+// yaml.v3 does not call the unmarshalers of struct fields for null values, it leaves them untouched:
+// a new value stays None, a reused value must be decoded with UnmarshalYAMLReset.
+func (o *Option[T]) UnmarshalYAML(node *yaml.Node) (err error) {
+	if isYAMLNull(node) {
+		o.value = nil
+		return
+	}
+
+	var v T
+	if err = node.Decode(&v); err != nil {
+		err = decodeerr.YAML(o.innerType(), node, err)
+		return
+	}
+	o.value = &v
+	return
+}
+
+// MarshalYAML indicates how to marshal an Option into a yaml value.
+// None is encoded as null.
+//
+// Option does not implement IsZero, so the bson output of `omitempty` fields is kept (None as null).
+// The `omitempty` tag option of yaml.v3 must not be used with Options: without exported fields,
+// every Option (Some included) is considered empty and omitted.
+func (o Option[T]) MarshalYAML() (v any, err error) {
+	if o.value == nil {
+		return
+	}
+	v = *o.value
+	return
+}
+
+// isYAMLNull returns true if the node is a null scalar (`~`, `null` or an empty value).
+func isYAMLNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
@@ -0,0 +1,190 @@
+package optional
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlDatabase struct {
+	Host Option[string] `yaml:"host"`
+	Port Option[int]    `yaml:"port"`
+}
+
+type yamlConfig struct {
+	Name     Option[string]        `yaml:"name"`
+	Debug    Option[bool]          `yaml:"debug"`
+	Timeout  Option[time.Duration] `yaml:"timeout"`
+	Ratio    Option[float64]       `yaml:"ratio"`
+	Tags     Option[[]string]      `yaml:"tags"`
+	Database Option[yamlDatabase]  `yaml:"database"`
+	Replicas []yamlDatabase        `yaml:"replicas,omitempty"`
+}
+
+// Tests for Option UnmarshalYAML
+func TestOption_UnmarshalYAML(t *testing.T) {
+	t.Run("YAML - values", func(t *testing.T) {
+		// arrange
+		data := []byte(`
+name: api
+debug: true
+timeout: 5s
+ratio: 0.5
+tags: [a, b]
+database:
+  host: localhost
+  port: 5432
+replicas:
+  - host: replica
+`)
+
+		// act
+		var config yamlConfig
+		err := yaml.Unmarshal(data, &config)
+
+		// assert
+		require.NoError(t, err)
+		expectedConfig := yamlConfig{
+			Name:     Some("api"),
+			Debug:    Some(true),
+			Timeout:  Some(5 * time.Second),
+			Ratio:    Some(0.5),
+			Tags:     Some([]string{"a", "b"}),
+			Database: Some(yamlDatabase{Host: Some("localhost"), Port: Some(5432)}),
+			Replicas: []yamlDatabase{{Host: Some("replica")}},
+		}
+		require.Equal(t, expectedConfig, config)
+	})
+
+	t.Run("YAML - null values are none", func(t *testing.T) {
+		// arrange
+		data := []byte(`
+name: ~
+debug: null
+timeout:
+tags: !!null
+`)
+
+		// act
+		var config yamlConfig
+		err := yaml.Unmarshal(data, &config)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, yamlConfig{}, config)
+	})
+
+	t.Run("YAML - null values reset a reused value", func(t *testing.T) {
+		// arrange
+		config := yamlConfig{Name: Some("stale"), Debug: Some(true), Ratio: Some(1.0)}
+
+		// act
+		err := UnmarshalYAMLReset([]byte("name: ~\ndebug: false\n"), &config)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, yamlConfig{Debug: Some(false)}, config)
+	})
+
+	t.Run("YAML - direct call with null", func(t *testing.T) {
+		// arrange
+		option := Some("stale")
+
+		// act
+		err := option.UnmarshalYAML(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "~"})
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, option.IsSome())
+	})
+
+	t.Run("YAML - quoted null is a string", func(t *testing.T) {
+		// act
+		var config yamlConfig
+		err := yaml.Unmarshal([]byte(`name: "null"`), &config)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "null", config.Name.Unwrap())
+	})
+
+	t.Run("YAML - invalid value", func(t *testing.T) {
+		// act
+		var config yamlConfig
+		err := yaml.Unmarshal([]byte(`database: {port: [1, 2]}`), &config)
+
+		// assert
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "yaml", decodeErr.Format)
+		require.Equal(t, "int", decodeErr.Type.String())
+		require.Equal(t, "[1, 2]", decodeErr.Raw)
+	})
+
+}
+
+// Tests for Option MarshalYAML
+func TestOption_MarshalYAML(t *testing.T) {
+	t.Run("YAML - values and nulls", func(t *testing.T) {
+		// arrange
+		config := yamlConfig{
+			Name:     Some("api"),
+			Timeout:  Some(5 * time.Second),
+			Database: Some(yamlDatabase{Host: Some("localhost")}),
+		}
+
+		// act
+		data, err := yaml.Marshal(config)
+
+		// assert
+		require.NoError(t, err)
+		expectedData := "name: api\ndebug: null\ntimeout: 5s\nratio: null\ntags: null\ndatabase:\n    host: localhost\n    port: null\n"
+		require.Equal(t, expectedData, string(data))
+	})
+
+	t.Run("YAML - round trip", func(t *testing.T) {
+		// arrange
+		config := yamlConfig{
+			Name:     Some("api"),
+			Debug:    Some(false),
+			Timeout:  Some(time.Minute),
+			Ratio:    Some(0.25),
+			Tags:     Some([]string{"x"}),
+			Database: Some(yamlDatabase{Host: Some("localhost"), Port: Some(5432)}),
+			Replicas: []yamlDatabase{{Host: Some("replica")}, {Port: Some(1)}},
+		}
+
+		// act
+		data, err := yaml.Marshal(config)
+		require.NoError(t, err)
+		var decoded yamlConfig
+		err = yaml.Unmarshal(data, &decoded)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, config, decoded)
+	})
+}
+
+// Tests for the bson output of omitempty Options
+func TestOption_OmitEmpty(t *testing.T) {
+	t.Run("None is kept as null by bson omitempty", func(t *testing.T) {
+		// arrange
+		type schema struct {
+			Name Option[string] `bson:"name,omitempty"`
+			Age  Option[int]    `bson:"age"`
+		}
+
+		// act
+		data, err := bson.Marshal(schema{})
+
+		// assert
+		require.NoError(t, err)
+		expectedData, err := bson.Marshal(bson.D{{Key: "name", Value: nil}, {Key: "age", Value: nil}})
+		require.NoError(t, err)
+		require.Equal(t, expectedData, data)
+	})
+}